@@ -0,0 +1,446 @@
+package clusters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Cluster types, that could be checked by `cluster_type` policy attribute
+const (
+	ClusterTypeAllPurpose = "all-purpose"
+	ClusterTypeJob        = "job"
+)
+
+// virtual policy attributes, that don't correspond to cluster attributes and can't be checked locally
+var virtualPolicyAttributes = map[string]bool{
+	"dbus_per_hour":         true,
+	"cluster_log_conf.type": true,
+	"cluster_log_conf.path": true,
+}
+
+// PolicyRule is a single rule of the cluster policy definition.
+// See https://docs.databricks.com/administration-guide/clusters/policy-definition.html
+type PolicyRule struct {
+	Type         string   `json:"type"`
+	Value        any      `json:"value,omitempty"`
+	Values       []any    `json:"values,omitempty"`
+	Pattern      string   `json:"pattern,omitempty"`
+	MinValue     *float64 `json:"minValue,omitempty"`
+	MaxValue     *float64 `json:"maxValue,omitempty"`
+	DefaultValue any      `json:"defaultValue,omitempty"`
+	IsOptional   bool     `json:"isOptional,omitempty"`
+	Hidden       bool     `json:"hidden,omitempty"`
+}
+
+// PolicyDefinition maps attribute paths to policy rules
+type PolicyDefinition map[string]PolicyRule
+
+// PolicyViolation describes a cluster attribute that doesn't comply with the policy rule
+type PolicyViolation struct {
+	Path    string
+	Message string
+}
+
+func (v PolicyViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
+
+// ParsePolicyDefinition parses JSON document of the cluster policy definition
+func ParsePolicyDefinition(definition string) (PolicyDefinition, error) {
+	var pd PolicyDefinition
+	if definition == "" {
+		return pd, nil
+	}
+	err := json.Unmarshal([]byte(definition), &pd)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse policy definition: %w", err)
+	}
+	return pd, nil
+}
+
+// flattenClusterAttributes returns values of cluster attributes, keyed by policy paths
+func flattenClusterAttributes(cluster Cluster) (map[string]any, error) {
+	raw, err := json.Marshal(cluster)
+	if err != nil {
+		return nil, err
+	}
+	var tree map[string]any
+	if err = json.Unmarshal(raw, &tree); err != nil {
+		return nil, err
+	}
+	flat := map[string]any{}
+	var walk func(prefix string, v any)
+	walk = func(prefix string, v any) {
+		switch t := v.(type) {
+		case map[string]any:
+			for k, nested := range t {
+				walk(prefix+k+".", nested)
+			}
+		case []any:
+			for i, nested := range t {
+				walk(fmt.Sprintf("%s%d.", prefix, i), nested)
+			}
+		default:
+			flat[strings.TrimSuffix(prefix, ".")] = v
+		}
+	}
+	walk("", tree)
+	return flat, nil
+}
+
+func policyValuesEqual(a, b any) bool {
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func policyValueIn(v any, values []any) bool {
+	for _, allowed := range values {
+		if policyValuesEqual(v, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// usesAutoValues returns true for rules with `auto:` values of spark_version, that are resolved by the backend
+func (rule PolicyRule) usesAutoValues() bool {
+	for _, v := range append([]any{rule.Value}, rule.Values...) {
+		if s, ok := v.(string); ok && strings.HasPrefix(s, "auto:") {
+			return true
+		}
+	}
+	return false
+}
+
+// isRequired returns true if the attribute must be specified in the cluster definition
+func (rule PolicyRule) isRequired() bool {
+	switch rule.Type {
+	case "allowlist", "blocklist", "regex", "range":
+		return !rule.IsOptional && rule.DefaultValue == nil
+	}
+	return false
+}
+
+func (rule PolicyRule) check(v any) (string, bool) {
+	switch rule.Type {
+	case "fixed":
+		if !policyValuesEqual(v, rule.Value) {
+			return fmt.Sprintf("value %v is different from the fixed value %v", v, rule.Value), false
+		}
+	case "forbidden":
+		return "attribute is forbidden by policy", false
+	case "allowlist":
+		if !policyValueIn(v, rule.Values) {
+			return fmt.Sprintf("value %v isn't in the list of allowed values %v", v, rule.Values), false
+		}
+	case "blocklist":
+		if policyValueIn(v, rule.Values) {
+			return fmt.Sprintf("value %v is in the list of blocked values %v", v, rule.Values), false
+		}
+	case "regex":
+		re, err := regexp.Compile("^(?:" + rule.Pattern + ")$")
+		if err != nil {
+			log.Printf("[WARN] Skipping policy rule with unsupported pattern %s: %v", rule.Pattern, err)
+			return "", true
+		}
+		if !re.MatchString(fmt.Sprint(v)) {
+			return fmt.Sprintf("value %v doesn't match pattern %s", v, rule.Pattern), false
+		}
+	case "range":
+		n, err := strconv.ParseFloat(fmt.Sprint(v), 64)
+		if err != nil {
+			return fmt.Sprintf("value %v isn't a number", v), false
+		}
+		if rule.MinValue != nil && n < *rule.MinValue {
+			return fmt.Sprintf("value %v is less than minimum %v", v, *rule.MinValue), false
+		}
+		if rule.MaxValue != nil && n > *rule.MaxValue {
+			return fmt.Sprintf("value %v is greater than maximum %v", v, *rule.MaxValue), false
+		}
+	}
+	return "", true
+}
+
+// Violations evaluates policy rules against the cluster. Rules for attributes, for which isKnown
+// returns false, are skipped. Cluster type is used for `cluster_type` attribute, if it's not empty.
+func (pd PolicyDefinition) Violations(cluster Cluster, clusterType string,
+	isKnown func(path string) bool) ([]PolicyViolation, error) {
+	attributes, err := flattenClusterAttributes(cluster)
+	if err != nil {
+		return nil, err
+	}
+	if clusterType != "" {
+		attributes["cluster_type"] = clusterType
+	}
+	paths := make([]string, 0, len(pd))
+	for path := range pd {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	violations := []PolicyViolation{}
+	for _, path := range paths {
+		rule := pd[path]
+		if virtualPolicyAttributes[path] || rule.usesAutoValues() {
+			continue
+		}
+		if path == "cluster_type" && clusterType == "" {
+			continue
+		}
+		// only one of the cluster size attributes is used
+		isAutoscalePath := strings.HasPrefix(path, "autoscale.")
+		if (cluster.Autoscale != nil && path == "num_workers") || (cluster.Autoscale == nil && isAutoscalePath) {
+			continue
+		}
+		if isKnown != nil && !isKnown(path) {
+			continue
+		}
+		var matched []string
+		if strings.Contains(path, "*") {
+			re := regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(path), `\*`, `[^.]+`) + "$")
+			for k := range attributes {
+				if re.MatchString(k) {
+					matched = append(matched, k)
+				}
+			}
+			sort.Strings(matched)
+		} else if _, ok := attributes[path]; ok {
+			matched = append(matched, path)
+		} else if rule.isRequired() {
+			violations = append(violations, PolicyViolation{
+				Path:    path,
+				Message: "attribute is required by policy",
+			})
+		}
+		for _, k := range matched {
+			if message, ok := rule.check(attributes[k]); !ok {
+				violations = append(violations, PolicyViolation{
+					Path:    k,
+					Message: message,
+				})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// terraformAddress converts policy path into address of the attribute in the Terraform plan.
+// Nested lists and maps are addressed as a whole. Empty string is returned for virtual attributes.
+func terraformAddress(s map[string]*schema.Schema, prefix, path string) string {
+	address := prefix
+	for _, segment := range strings.Split(path, ".") {
+		fieldSchema, ok := s[segment]
+		if !ok {
+			break
+		}
+		address += segment
+		nested, isResource := fieldSchema.Elem.(*schema.Resource)
+		if fieldSchema.Type != schema.TypeList || !isResource || fieldSchema.MaxItems != 1 {
+			return address
+		}
+		address += ".0."
+		s = nested.Schema
+	}
+	if address == prefix {
+		return ""
+	}
+	return strings.TrimSuffix(address, ".")
+}
+
+// ClusterPolicyChecker evaluates planned clusters against definitions of their cluster policies
+type ClusterPolicyChecker struct {
+	ctx         context.Context
+	client      *common.DatabricksClient
+	diff        *schema.ResourceDiff
+	definitions map[string]PolicyDefinition
+}
+
+// NewClusterPolicyChecker creates a checker that caches fetched policies for the duration of the diff
+func NewClusterPolicyChecker(ctx context.Context, c *common.DatabricksClient,
+	d *schema.ResourceDiff) *ClusterPolicyChecker {
+	return &ClusterPolicyChecker{
+		ctx:         ctx,
+		client:      c,
+		diff:        d,
+		definitions: map[string]PolicyDefinition{},
+	}
+}
+
+// GetPolicyDefinition fetches the cluster policy and parses its definition
+func GetPolicyDefinition(ctx context.Context, c *common.DatabricksClient, policyID string) (PolicyDefinition, error) {
+	w, err := c.WorkspaceClient()
+	if err != nil {
+		return nil, err
+	}
+	policy, err := w.ClusterPolicies.GetByPolicyId(ctx, policyID)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePolicyDefinition(policy.Definition)
+}
+
+func (pc *ClusterPolicyChecker) definition(policyID string) PolicyDefinition {
+	if pd, ok := pc.definitions[policyID]; ok {
+		return pd
+	}
+	// the policy is fetched only once, even if it can't be fetched
+	pc.definitions[policyID] = nil
+	pd, err := GetPolicyDefinition(pc.ctx, pc.client, policyID)
+	if err != nil {
+		log.Printf("[WARN] Skipping check of cluster policy %s: %v", policyID, err)
+		return nil
+	}
+	pc.definitions[policyID] = pd
+	return pd
+}
+
+// Check returns violations of the policy referenced by the cluster. Prefix is the address of
+// the cluster block in Terraform plan, i.e. `task.0.new_cluster.0.`
+func (pc *ClusterPolicyChecker) Check(prefix string, cluster Cluster, clusterType string) []PolicyViolation {
+	if cluster.PolicyID == "" || !pc.diff.NewValueKnown(prefix+"policy_id") {
+		return nil
+	}
+	pd := pc.definition(cluster.PolicyID)
+	if pd == nil {
+		return nil
+	}
+	violations, err := pd.Violations(cluster, clusterType, func(path string) bool {
+		address := terraformAddress(clusterSchema, prefix, path)
+		return address == "" || pc.diff.NewValueKnown(address)
+	})
+	if err != nil {
+		log.Printf("[WARN] Skipping check of cluster policy %s: %v", cluster.PolicyID, err)
+		return nil
+	}
+	return violations
+}
+
+// SetDefaultValues puts values from the policy into the plan for computed attributes of the cluster,
+// that aren't known yet. Only top-level attributes of the resource could be changed this way.
+func (pc *ClusterPolicyChecker) SetDefaultValues(cluster Cluster) error {
+	if cluster.PolicyID == "" || !pc.diff.NewValueKnown("policy_id") {
+		return nil
+	}
+	pd := pc.definition(cluster.PolicyID)
+	for path, value := range pd.providedValues() {
+		fieldSchema, ok := clusterSchema[path]
+		if !ok || !fieldSchema.Computed || pc.diff.NewValueKnown(path) {
+			continue
+		}
+		var v any
+		switch fieldSchema.Type {
+		case schema.TypeString:
+			v = fmt.Sprint(value)
+		case schema.TypeBool:
+			v, ok = value.(bool)
+		case schema.TypeInt:
+			var f float64
+			f, ok = value.(float64)
+			v = int(f)
+		default:
+			ok = false
+		}
+		if !ok {
+			continue
+		}
+		if err := pc.diff.SetNew(path, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// providedValues returns values that backend sets from the policy, when attributes aren't specified
+func (pd PolicyDefinition) providedValues() map[string]any {
+	values := map[string]any{}
+	for path, rule := range pd {
+		if strings.Contains(path, "*") || virtualPolicyAttributes[path] || rule.usesAutoValues() {
+			continue
+		}
+		if rule.Type == "fixed" {
+			values[path] = rule.Value
+		} else if rule.DefaultValue != nil {
+			values[path] = rule.DefaultValue
+		}
+	}
+	return values
+}
+
+func toJSONTree(v any) (map[string]any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var tree map[string]any
+	err = json.Unmarshal(raw, &tree)
+	return tree, err
+}
+
+// policyPathParent finds the object with the attribute addressed by the policy path and the key of the
+// attribute in it. Keys of maps may contain dots, like in `spark_conf.spark.databricks.io.cache.enabled`
+func policyPathParent(tree map[string]any, path string) (map[string]any, string, bool) {
+	node := tree
+	for {
+		if _, ok := node[path]; ok {
+			return node, path, true
+		}
+		head, rest, found := strings.Cut(path, ".")
+		if !found {
+			return nil, "", false
+		}
+		nested, ok := node[head].(map[string]any)
+		if !ok {
+			return nil, "", false
+		}
+		node, path = nested, rest
+	}
+}
+
+// RemovePolicyDefaultValues removes attributes, that were set by the backend from fixed and default values
+// of the policy, but aren't specified in the configured cluster, so that they don't cause configuration drift
+func RemovePolicyDefaultValues[T any](pd PolicyDefinition, configured Cluster, actual *T) error {
+	configuredTree, err := toJSONTree(configured)
+	if err != nil {
+		return err
+	}
+	actualTree, err := toJSONTree(*actual)
+	if err != nil {
+		return err
+	}
+	for path, value := range pd.providedValues() {
+		if _, _, ok := policyPathParent(configuredTree, path); ok {
+			continue
+		}
+		parent, key, ok := policyPathParent(actualTree, path)
+		if !ok || !policyValuesEqual(parent[key], value) {
+			continue
+		}
+		log.Printf("[DEBUG] Ignoring %s = %v, as it's set from the cluster policy %s", path, value, configured.PolicyID)
+		delete(parent, key)
+	}
+	raw, err := json.Marshal(actualTree)
+	if err != nil {
+		return err
+	}
+	var cleaned T
+	if err = json.Unmarshal(raw, &cleaned); err != nil {
+		return err
+	}
+	*actual = cleaned
+	return nil
+}
+
+// PolicyViolationsError returns error with all given violations, or nil if there are none
+func PolicyViolationsError(violations []string) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("cluster doesn't comply with the cluster policy: %s", strings.Join(violations, "; "))
+}
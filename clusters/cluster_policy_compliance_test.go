@@ -0,0 +1,294 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/libraries"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyViolations(t *testing.T) {
+	pd, err := ParsePolicyDefinition(`{
+		"spark_version": {"type": "regex", "pattern": "13\\.[0-9]+\\.x-scala.*"},
+		"node_type_id": {"type": "allowlist", "values": ["i3.xlarge", "i3.2xlarge"]},
+		"driver_node_type_id": {"type": "blocklist", "values": ["i3.16xlarge"], "isOptional": true},
+		"autoscale.max_workers": {"type": "range", "maxValue": 10},
+		"autotermination_minutes": {"type": "fixed", "value": 30},
+		"custom_tags.team": {"type": "fixed", "value": "data"},
+		"spark_conf.spark.databricks.io.cache.enabled": {"type": "unlimited", "defaultValue": "true"},
+		"init_scripts.*.dbfs.destination": {"type": "forbidden"},
+		"instance_pool_id": {"type": "forbidden", "hidden": true},
+		"cluster_type": {"type": "fixed", "value": "job"},
+		"dbus_per_hour": {"type": "range", "maxValue": 5},
+		"single_user_name": {"type": "regex", "pattern": ".*@example.com"}
+	}`)
+	require.NoError(t, err)
+	violations, err := pd.Violations(Cluster{
+		SparkVersion:           "12.2.x-scala2.12",
+		NodeTypeID:             "m5.large",
+		DriverNodeTypeID:       "i3.16xlarge",
+		Autoscale:              &AutoScale{MinWorkers: 1, MaxWorkers: 20},
+		AutoterminationMinutes: 60,
+		CustomTags:             map[string]string{"team": "data"},
+		InitScripts: []InitScriptStorageInfo{
+			{Workspace: &WorkspaceFileInfo{Destination: "/Shared/init.sh"}},
+			{Dbfs: &DbfsStorageInfo{Destination: "dbfs:/init.sh"}},
+		},
+	}, ClusterTypeAllPurpose, nil)
+	require.NoError(t, err)
+	actual := []string{}
+	for _, v := range violations {
+		actual = append(actual, v.String())
+	}
+	assert.Equal(t, []string{
+		"autoscale.max_workers: value 20 is greater than maximum 10",
+		"autotermination_minutes: value 60 is different from the fixed value 30",
+		"cluster_type: value all-purpose is different from the fixed value job",
+		"driver_node_type_id: value i3.16xlarge is in the list of blocked values [i3.16xlarge]",
+		"init_scripts.1.dbfs.destination: attribute is forbidden by policy",
+		"node_type_id: value m5.large isn't in the list of allowed values [i3.xlarge i3.2xlarge]",
+		"single_user_name: attribute is required by policy",
+		"spark_version: value 12.2.x-scala2.12 doesn't match pattern 13\\.[0-9]+\\.x-scala.*",
+	}, actual)
+}
+
+func TestPolicyViolationsSkipsUnknownAndAutoValues(t *testing.T) {
+	pd, err := ParsePolicyDefinition(`{
+		"spark_version": {"type": "fixed", "value": "auto:latest-lts"},
+		"node_type_id": {"type": "allowlist", "values": ["i3.xlarge"]},
+		"num_workers": {"type": "range", "minValue": 2},
+		"cluster_type": {"type": "fixed", "value": "job"}
+	}`)
+	require.NoError(t, err)
+	violations, err := pd.Violations(Cluster{
+		SparkVersion: "13.3.x-scala2.12",
+		Autoscale:    &AutoScale{MinWorkers: 1, MaxWorkers: 2},
+	}, "", func(path string) bool {
+		return path != "node_type_id"
+	})
+	require.NoError(t, err)
+	assert.Len(t, violations, 0)
+}
+
+func TestParsePolicyDefinitionError(t *testing.T) {
+	_, err := ParsePolicyDefinition(`{`)
+	assert.ErrorContains(t, err, "can't parse policy definition")
+}
+
+func TestTerraformAddress(t *testing.T) {
+	assert.Equal(t, "autoscale.0.max_workers", terraformAddress(clusterSchema, "", "autoscale.max_workers"))
+	assert.Equal(t, "task.0.new_cluster.0.spark_conf",
+		terraformAddress(clusterSchema, "task.0.new_cluster.0.", "spark_conf.spark.databricks.io.cache.enabled"))
+	assert.Equal(t, "init_scripts", terraformAddress(clusterSchema, "", "init_scripts.*.dbfs.destination"))
+	assert.Equal(t, "", terraformAddress(clusterSchema, "", "dbus_per_hour"))
+}
+
+func TestResourceClusterCreate_PolicyViolations(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: compute.Policy{
+					PolicyId:   "abc",
+					Definition: `{"autotermination_minutes": {"type": "range", "maxValue": 60}, "custom_tags.team": {"type": "fixed", "value": "data"}}`,
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Shared Autoscaling"
+		spark_version = "13.3.x-scala2.12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		autotermination_minutes = 120
+		policy_id = "abc"
+		custom_tags = {
+			team = "ml"
+		}`,
+	}.ExpectError(t, "cluster doesn't comply with the cluster policy: "+
+		"autotermination_minutes: value 120 is greater than maximum 60; "+
+		"custom_tags.team: value ml is different from the fixed value data")
+}
+
+func TestResourceClusterCreate_PolicyIsNotAvailable(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: map[string]any{
+					"error_code": "PERMISSION_DENIED",
+					"message":    "no access",
+				},
+				Status: 403,
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					ClusterName:            "Shared Autoscaling",
+					SparkVersion:           "13.3.x-scala2.12",
+					NodeTypeID:             "i3.xlarge",
+					NumWorkers:             1,
+					AutoterminationMinutes: 120,
+					PolicyID:               "abc",
+					State:                  ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				ExpectedRequest: EventsRequest{
+					ClusterID:  "abc",
+					Limit:      1,
+					Order:      SortDescending,
+					EventTypes: []ClusterEventType{EvTypePinned, EvTypeUnpinned},
+				},
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Shared Autoscaling"
+		spark_version = "13.3.x-scala2.12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		autotermination_minutes = 120
+		policy_id = "abc"`,
+	}.ApplyNoError(t)
+}
+
+func TestRemovePolicyDefaultValues(t *testing.T) {
+	pd, err := ParsePolicyDefinition(`{
+		"spark_conf.spark.databricks.io.cache.enabled": {"type": "unlimited", "defaultValue": "true"},
+		"custom_tags.team": {"type": "fixed", "value": "data"},
+		"autotermination_minutes": {"type": "range", "maxValue": 60, "defaultValue": 30},
+		"aws_attributes.availability": {"type": "fixed", "value": "SPOT"},
+		"node_type_id": {"type": "allowlist", "values": ["i3.xlarge"], "defaultValue": "i3.xlarge"}
+	}`)
+	require.NoError(t, err)
+	actual := ClusterInfo{
+		ClusterID:              "abc",
+		NodeTypeID:             "i3.2xlarge",
+		AutoterminationMinutes: 30,
+		SparkConf: map[string]string{
+			"spark.databricks.io.cache.enabled": "true",
+			"spark.speculation":                 "true",
+		},
+		CustomTags:    map[string]string{"team": "data"},
+		AwsAttributes: &AwsAttributes{Availability: "SPOT", ZoneID: "us-east-1a"},
+	}
+	err = RemovePolicyDefaultValues(pd, Cluster{
+		PolicyID:               "abc",
+		AutoterminationMinutes: 30,
+	}, &actual)
+	require.NoError(t, err)
+	assert.Equal(t, ClusterInfo{
+		ClusterID:              "abc",
+		NodeTypeID:             "i3.2xlarge",
+		AutoterminationMinutes: 30,
+		SparkConf: map[string]string{
+			"spark.speculation": "true",
+		},
+		CustomTags:    map[string]string{},
+		AwsAttributes: &AwsAttributes{ZoneID: "us-east-1a"},
+	}, actual)
+}
+
+func TestResourceClusterCreate_ApplyPolicyDefaultValues(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: compute.Policy{
+					PolicyId:   "abc",
+					Definition: `{"node_type_id": {"type": "unlimited", "defaultValue": "i3.xlarge"}, "custom_tags.team": {"type": "fixed", "value": "data"}}`,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					NumWorkers:               1,
+					ClusterName:              "Shared Autoscaling",
+					SparkVersion:             "13.3.x-scala2.12",
+					NodeTypeID:               "i3.xlarge",
+					AutoterminationMinutes:   60,
+					PolicyID:                 "abc",
+					ApplyPolicyDefaultValues: true,
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					ClusterName:            "Shared Autoscaling",
+					SparkVersion:           "13.3.x-scala2.12",
+					NodeTypeID:             "i3.xlarge",
+					NumWorkers:             1,
+					AutoterminationMinutes: 60,
+					PolicyID:               "abc",
+					CustomTags:             map[string]string{"team": "data"},
+					State:                  ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				ExpectedRequest: EventsRequest{
+					ClusterID:  "abc",
+					Limit:      1,
+					Order:      SortDescending,
+					EventTypes: []ClusterEventType{EvTypePinned, EvTypeUnpinned},
+				},
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Shared Autoscaling"
+		spark_version = "13.3.x-scala2.12"
+		num_workers = 1
+		policy_id = "abc"
+		apply_policy_default_values = true`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "i3.xlarge", d.Get("node_type_id"))
+	assert.Equal(t, map[string]any{}, d.Get("custom_tags"))
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
 
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -845,6 +846,113 @@ func (a ClustersAPI) Events(eventsRequest EventsRequest) ([]ClusterEvent, error)
 	return events[0:curPos], err
 }
 
+// maxActiveRunsScanned limits how many active job runs of the workspace are scanned for the cluster
+const maxActiveRunsScanned = 500
+
+// activityDetector describes the activity on the cluster, like active job runs, executed commands
+// or autoscaling. It remembers the active job run found by the previous check, so that active runs
+// of the whole workspace aren't listed again while that run is active.
+type activityDetector struct {
+	clusters ClustersAPI
+	runID    int64
+}
+
+// ActivitySince returns empty string if the cluster was idle since the given time. There's no API
+// to list running commands, so they are detected by the last activity time of the cluster, which is
+// updated when commands are executed. Job runs are listed only when there's no other activity.
+func (a *activityDetector) ActivitySince(info ClusterInfo, since time.Time) (string, error) {
+	if info.LastActivityTime >= since.UnixMilli() {
+		return fmt.Sprintf("cluster %s had activity at %s", info.ClusterID,
+			time.UnixMilli(info.LastActivityTime).UTC().Format(time.RFC3339)), nil
+	}
+	events, err := a.clusters.Events(EventsRequest{
+		ClusterID:  info.ClusterID,
+		StartTime:  since.UnixMilli(),
+		Order:      SortDescending,
+		EventTypes: []ClusterEventType{EvTypeResizing, EvTypeUpsizeCompleted},
+		Limit:      1,
+		MaxItems:   1,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(events) > 0 {
+		return fmt.Sprintf("cluster %s had %s event at %s", info.ClusterID, events[0].Type,
+			time.UnixMilli(events[0].Timestamp).UTC().Format(time.RFC3339)), nil
+	}
+	w, err := a.clusters.client.WorkspaceClient()
+	if err != nil {
+		return "", err
+	}
+	if a.runID != 0 {
+		active, err := isRunActive(a.clusters.context, w.Jobs, a.runID)
+		if err != nil {
+			return "", err
+		}
+		if !active {
+			a.runID = 0
+		}
+	}
+	if a.runID == 0 {
+		a.runID, err = activeJobRun(a.clusters.context, w.Jobs, info.ClusterID)
+		if err != nil {
+			return "", err
+		}
+	}
+	if a.runID != 0 {
+		return fmt.Sprintf("cluster %s is used by active job run %d", info.ClusterID, a.runID), nil
+	}
+	return "", nil
+}
+
+func isRunActive(ctx context.Context, runs *jobs.JobsAPI, runID int64) (bool, error) {
+	run, err := runs.GetRun(ctx, jobs.GetRunRequest{
+		RunId: runID,
+	})
+	if apierr.IsMissing(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if run.State == nil {
+		return false, nil
+	}
+	switch run.State.LifeCycleState {
+	case jobs.RunLifeCycleStateTerminated, jobs.RunLifeCycleStateSkipped, jobs.RunLifeCycleStateInternalError:
+		return false, nil
+	}
+	return true, nil
+}
+
+// activeJobRun returns ID of an active job run, that has a task on the given cluster, or 0 otherwise
+func activeJobRun(ctx context.Context, runs *jobs.JobsAPI, clusterID string) (int64, error) {
+	it := runs.ListRuns(ctx, jobs.ListRunsRequest{
+		ActiveOnly:  true,
+		ExpandTasks: true,
+		Limit:       25,
+	})
+	for scanned := 0; it.HasNext(ctx); scanned++ {
+		if scanned == maxActiveRunsScanned {
+			log.Printf("[WARN] Only %d active job runs are checked for cluster %s", scanned, clusterID)
+			break
+		}
+		run, err := it.Next(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if run.ClusterInstance != nil && run.ClusterInstance.ClusterId == clusterID {
+			return run.RunId, nil
+		}
+		for _, task := range run.Tasks {
+			if task.ClusterInstance != nil && task.ClusterInstance.ClusterId == clusterID {
+				return run.RunId, nil
+			}
+		}
+	}
+	return 0, nil
+}
+
 // List return information about all pinned clusters, currently active clusters,
 // up to 70 of the most recently terminated interactive clusters in the past 30 days,
 // and up to 30 of the most recently terminated job clusters in the past 30 days
@@ -0,0 +1,217 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	SkuAllPurposeCompute = "ALL_PURPOSE_COMPUTE"
+	SkuSqlProCompute     = "SQL_PRO_COMPUTE"
+)
+
+// sqlWarehouseDbusPerHour is the published DBU consumption of a single cluster of SQL warehouse of a given size,
+// that is used if the size isn't in the pricing table
+var sqlWarehouseDbusPerHour = map[string]float64{
+	"2X-Small": 4,
+	"X-Small":  6,
+	"Small":    12,
+	"Medium":   24,
+	"Large":    40,
+	"X-Large":  80,
+	"2X-Large": 144,
+	"3X-Large": 272,
+	"4X-Large": 528,
+}
+
+type nodeTypePrice struct {
+	NodeTypeID          string  `json:"node_type_id"`
+	DbusPerHour         float64 `json:"dbus_per_hour"`
+	InstanceCostPerHour float64 `json:"instance_cost_per_hour,omitempty"`
+}
+
+type warehouseSizePrice struct {
+	ClusterSize string  `json:"cluster_size"`
+	DbusPerHour float64 `json:"dbus_per_hour"`
+}
+
+type skuPrice struct {
+	Name        string  `json:"name"`
+	PricePerDbu float64 `json:"price_per_dbu"`
+}
+
+type clusterCostSpec struct {
+	NodeTypeID       string     `json:"node_type_id"`
+	DriverNodeTypeID string     `json:"driver_node_type_id,omitempty"`
+	NumWorkers       int32      `json:"num_workers,omitempty"`
+	Autoscale        *AutoScale `json:"autoscale,omitempty"`
+	Sku              string     `json:"sku,omitempty"`
+}
+
+type instancePoolCostSpec struct {
+	NodeTypeID       string `json:"node_type_id"`
+	MinIdleInstances int32  `json:"min_idle_instances,omitempty"`
+	MaxCapacity      int32  `json:"max_capacity,omitempty"`
+}
+
+type sqlWarehouseCostSpec struct {
+	ClusterSize    string `json:"cluster_size"`
+	MinNumClusters int32  `json:"min_num_clusters,omitempty"`
+	MaxNumClusters int32  `json:"max_num_clusters,omitempty"`
+	Sku            string `json:"sku,omitempty"`
+}
+
+type computeCostEstimate struct {
+	Cluster        *clusterCostSpec      `json:"cluster,omitempty"`
+	InstancePool   *instancePoolCostSpec `json:"instance_pool,omitempty"`
+	SqlWarehouse   *sqlWarehouseCostSpec `json:"sql_warehouse,omitempty"`
+	NodeTypes      []nodeTypePrice       `json:"node_types,omitempty" tf:"alias:node_type"`
+	WarehouseSizes []warehouseSizePrice  `json:"warehouse_sizes,omitempty" tf:"alias:warehouse_size"`
+	Skus           []skuPrice            `json:"skus,omitempty" tf:"alias:sku"`
+	MinDbusPerHour float64               `json:"min_dbus_per_hour,omitempty" tf:"computed"`
+	MaxDbusPerHour float64               `json:"max_dbus_per_hour,omitempty" tf:"computed"`
+	MinCostPerHour float64               `json:"min_cost_per_hour,omitempty" tf:"computed"`
+	MaxCostPerHour float64               `json:"max_cost_per_hour,omitempty" tf:"computed"`
+}
+
+func (e *computeCostEstimate) nodeType(id string) (nodeTypePrice, error) {
+	for _, nt := range e.NodeTypes {
+		if nt.NodeTypeID == id {
+			return nt, nil
+		}
+	}
+	return nodeTypePrice{}, fmt.Errorf("node type %s is not in the pricing table", id)
+}
+
+func (e *computeCostEstimate) warehouseDbusPerHour(clusterSize string) (float64, error) {
+	for _, ws := range e.WarehouseSizes {
+		if ws.ClusterSize == clusterSize {
+			return ws.DbusPerHour, nil
+		}
+	}
+	dbus, ok := sqlWarehouseDbusPerHour[clusterSize]
+	if !ok {
+		return 0, fmt.Errorf("unknown cluster size: %s", clusterSize)
+	}
+	return dbus, nil
+}
+
+func (e *computeCostEstimate) pricePerDbu(sku string) (float64, error) {
+	for _, s := range e.Skus {
+		if s.Name == sku {
+			return s.PricePerDbu, nil
+		}
+	}
+	return 0, fmt.Errorf("SKU %s is not in the pricing table", sku)
+}
+
+// estimate sets DBUs and cost per hour for the given DBUs & instance cost of the smallest and the largest size
+func (e *computeCostEstimate) estimate(sku string, minDbus, maxDbus, minInstanceCost, maxInstanceCost float64) error {
+	price := 0.0
+	if minDbus > 0 || maxDbus > 0 {
+		var err error
+		price, err = e.pricePerDbu(sku)
+		if err != nil {
+			return err
+		}
+	}
+	e.MinDbusPerHour = minDbus
+	e.MaxDbusPerHour = maxDbus
+	e.MinCostPerHour = minDbus*price + minInstanceCost
+	e.MaxCostPerHour = maxDbus*price + maxInstanceCost
+	return nil
+}
+
+func (e *computeCostEstimate) estimateCluster() error {
+	spec := e.Cluster
+	worker, err := e.nodeType(spec.NodeTypeID)
+	if err != nil {
+		return err
+	}
+	driver := worker
+	if spec.DriverNodeTypeID != "" {
+		driver, err = e.nodeType(spec.DriverNodeTypeID)
+		if err != nil {
+			return err
+		}
+	}
+	minWorkers, maxWorkers := spec.NumWorkers, spec.NumWorkers
+	if spec.Autoscale != nil {
+		minWorkers, maxWorkers = spec.Autoscale.MinWorkers, spec.Autoscale.MaxWorkers
+	}
+	sku := spec.Sku
+	if sku == "" {
+		sku = SkuAllPurposeCompute
+	}
+	return e.estimate(sku,
+		driver.DbusPerHour+float64(minWorkers)*worker.DbusPerHour,
+		driver.DbusPerHour+float64(maxWorkers)*worker.DbusPerHour,
+		driver.InstanceCostPerHour+float64(minWorkers)*worker.InstanceCostPerHour,
+		driver.InstanceCostPerHour+float64(maxWorkers)*worker.InstanceCostPerHour)
+}
+
+// estimateInstancePool estimates cost of idle instances, because DBUs are charged only
+// for clusters that are using instances of the pool
+func (e *computeCostEstimate) estimateInstancePool() error {
+	spec := e.InstancePool
+	nt, err := e.nodeType(spec.NodeTypeID)
+	if err != nil {
+		return err
+	}
+	maxIdle := spec.MinIdleInstances
+	if spec.MaxCapacity > maxIdle {
+		maxIdle = spec.MaxCapacity
+	}
+	return e.estimate("", 0, 0,
+		float64(spec.MinIdleInstances)*nt.InstanceCostPerHour,
+		float64(maxIdle)*nt.InstanceCostPerHour)
+}
+
+func (e *computeCostEstimate) estimateSqlWarehouse() error {
+	spec := e.SqlWarehouse
+	dbus, err := e.warehouseDbusPerHour(spec.ClusterSize)
+	if err != nil {
+		return err
+	}
+	minClusters := spec.MinNumClusters
+	if minClusters == 0 {
+		minClusters = 1
+	}
+	maxClusters := spec.MaxNumClusters
+	if maxClusters < minClusters {
+		maxClusters = minClusters
+	}
+	sku := spec.Sku
+	if sku == "" {
+		sku = SkuSqlProCompute
+	}
+	return e.estimate(sku, float64(minClusters)*dbus, float64(maxClusters)*dbus, 0, 0)
+}
+
+// DataSourceComputeCostEstimate estimates DBUs and cost per hour of a cluster, an instance pool
+// or a SQL warehouse using the pricing table supplied in the configuration
+func DataSourceComputeCostEstimate() *schema.Resource {
+	return common.WorkspaceData(func(ctx context.Context, data *computeCostEstimate, _ *databricks.WorkspaceClient) error {
+		specs := 0
+		for _, spec := range []bool{data.Cluster != nil, data.InstancePool != nil, data.SqlWarehouse != nil} {
+			if spec {
+				specs++
+			}
+		}
+		if specs != 1 {
+			return fmt.Errorf("exactly one of `cluster`, `instance_pool` or `sql_warehouse` must be specified")
+		}
+		switch {
+		case data.Cluster != nil:
+			return data.estimateCluster()
+		case data.InstancePool != nil:
+			return data.estimateInstancePool()
+		default:
+			return data.estimateSqlWarehouse()
+		}
+	})
+}
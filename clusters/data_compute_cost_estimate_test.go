@@ -0,0 +1,168 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPricingTable = `
+node_type {
+	node_type_id = "i3.xlarge"
+	dbus_per_hour = 1
+	instance_cost_per_hour = 0.312
+}
+
+node_type {
+	node_type_id = "i3.2xlarge"
+	dbus_per_hour = 2
+	instance_cost_per_hour = 0.624
+}
+
+sku {
+	name = "ALL_PURPOSE_COMPUTE"
+	price_per_dbu = 0.55
+}
+
+sku {
+	name = "JOBS_COMPUTE"
+	price_per_dbu = 0.15
+}
+
+sku {
+	name = "SQL_PRO_COMPUTE"
+	price_per_dbu = 0.55
+}`
+
+func TestComputeCostEstimate_AutoscalingCluster(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Read:        true,
+		Resource:    DataSourceComputeCostEstimate(),
+		NonWritable: true,
+		ID:          "_",
+		HCL: testPricingTable + `
+		cluster {
+			node_type_id = "i3.xlarge"
+			driver_node_type_id = "i3.2xlarge"
+			autoscale {
+				min_workers = 1
+				max_workers = 10
+			}
+			sku = "JOBS_COMPUTE"
+		}`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.InDelta(t, 3.0, d.Get("min_dbus_per_hour"), 0.0001)
+	assert.InDelta(t, 12.0, d.Get("max_dbus_per_hour"), 0.0001)
+	assert.InDelta(t, 3*0.15+0.624+0.312, d.Get("min_cost_per_hour"), 0.0001)
+	assert.InDelta(t, 12*0.15+0.624+10*0.312, d.Get("max_cost_per_hour"), 0.0001)
+}
+
+func TestComputeCostEstimate_FixedSizeCluster(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Read:        true,
+		Resource:    DataSourceComputeCostEstimate(),
+		NonWritable: true,
+		ID:          "_",
+		HCL: testPricingTable + `
+		cluster {
+			node_type_id = "i3.xlarge"
+			num_workers = 2
+		}`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.InDelta(t, 3.0, d.Get("min_dbus_per_hour"), 0.0001)
+	assert.InDelta(t, 3.0, d.Get("max_dbus_per_hour"), 0.0001)
+	assert.InDelta(t, 3*0.55+3*0.312, d.Get("max_cost_per_hour"), 0.0001)
+}
+
+func TestComputeCostEstimate_InstancePool(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Read:        true,
+		Resource:    DataSourceComputeCostEstimate(),
+		NonWritable: true,
+		ID:          "_",
+		HCL: testPricingTable + `
+		instance_pool {
+			node_type_id = "i3.xlarge"
+			min_idle_instances = 2
+			max_capacity = 10
+		}`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.0, d.Get("max_dbus_per_hour"), 0.0001)
+	assert.InDelta(t, 2*0.312, d.Get("min_cost_per_hour"), 0.0001)
+	assert.InDelta(t, 10*0.312, d.Get("max_cost_per_hour"), 0.0001)
+}
+
+func TestComputeCostEstimate_SqlWarehouse(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Read:        true,
+		Resource:    DataSourceComputeCostEstimate(),
+		NonWritable: true,
+		ID:          "_",
+		HCL: testPricingTable + `
+		sql_warehouse {
+			cluster_size = "Small"
+			max_num_clusters = 3
+		}`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.InDelta(t, 12.0, d.Get("min_dbus_per_hour"), 0.0001)
+	assert.InDelta(t, 36.0, d.Get("max_dbus_per_hour"), 0.0001)
+	assert.InDelta(t, 36*0.55, d.Get("max_cost_per_hour"), 0.0001)
+}
+
+func TestComputeCostEstimate_SqlWarehouseSizeFromPricingTable(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Read:        true,
+		Resource:    DataSourceComputeCostEstimate(),
+		NonWritable: true,
+		ID:          "_",
+		HCL: testPricingTable + `
+		warehouse_size {
+			cluster_size = "Small"
+			dbus_per_hour = 16
+		}
+
+		warehouse_size {
+			cluster_size = "5X-Large"
+			dbus_per_hour = 1024
+		}
+
+		sql_warehouse {
+			cluster_size = "Small"
+			max_num_clusters = 2
+		}`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.InDelta(t, 16.0, d.Get("min_dbus_per_hour"), 0.0001)
+	assert.InDelta(t, 32.0, d.Get("max_dbus_per_hour"), 0.0001)
+	assert.InDelta(t, 32*0.55, d.Get("max_cost_per_hour"), 0.0001)
+}
+
+func TestComputeCostEstimate_Errors(t *testing.T) {
+	for hcl, message := range map[string]string{
+		``: "exactly one of `cluster`, `instance_pool` or `sql_warehouse` must be specified",
+		`cluster {
+			node_type_id = "m5.large"
+		}`: "node type m5.large is not in the pricing table",
+		`cluster {
+			node_type_id = "i3.xlarge"
+			sku = "DLT_ADVANCED_COMPUTE"
+		}`: "SKU DLT_ADVANCED_COMPUTE is not in the pricing table",
+		`sql_warehouse {
+			cluster_size = "Tiny"
+		}`: "unknown cluster size: Tiny",
+	} {
+		qa.ResourceFixture{
+			Read:        true,
+			Resource:    DataSourceComputeCostEstimate(),
+			NonWritable: true,
+			ID:          "_",
+			HCL:         testPricingTable + "\n" + hcl,
+		}.ExpectError(t, message)
+	}
+}
@@ -2,9 +2,12 @@ package clusters
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"sort"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -17,6 +20,20 @@ const DefaultProvisionTimeout = 30 * time.Minute
 
 const DbfsDeprecationWarning = "For init scripts use 'volumes', 'workspace' or cloud storage location instead of 'dbfs'."
 
+// Strategies of applying changes that require restart of a running cluster
+const (
+	UpdateStrategyImmediate            = "immediate"
+	UpdateStrategyWaitForIdle          = "wait_for_idle"
+	UpdateStrategyDeferUntilTerminated = "defer_until_terminated"
+)
+
+// clusterIdlePeriod is how long a cluster must not run commands or autoscale to be considered idle
+const clusterIdlePeriod = 10 * time.Minute
+
+// defaultUpdateWaitMinutes is how long update strategies wait for the cluster to become idle or terminated.
+// The update timeout covers both the wait and the restart of the cluster, so it has to be increased for them.
+const defaultUpdateWaitMinutes = 30
+
 var clusterSchema = resourceClusterSchema()
 
 // ResourceCluster - returns Cluster resource description
@@ -29,6 +46,25 @@ func ResourceCluster() *schema.Resource {
 			d *schema.ResourceData, c *common.DatabricksClient) error {
 			return NewClustersAPI(ctx, c).PermanentDelete(d.Id())
 		},
+		CustomizeDiffWithClient: func(ctx context.Context, d *schema.ResourceDiff, c *common.DatabricksClient) error {
+			if d.Id() != "" && len(d.GetChangedKeysPrefix("")) == 0 {
+				return nil
+			}
+			var cluster Cluster
+			common.DiffToStructPointer(d, clusterSchema, &cluster)
+			checker := NewClusterPolicyChecker(ctx, c, d)
+			if cluster.ApplyPolicyDefaultValues {
+				if err := checker.SetDefaultValues(cluster); err != nil {
+					return err
+				}
+				common.DiffToStructPointer(d, clusterSchema, &cluster)
+			}
+			var violations []string
+			for _, v := range checker.Check("", cluster, ClusterTypeAllPurpose) {
+				violations = append(violations, v.String())
+			}
+			return PolicyViolationsError(violations)
+		},
 		Schema:        clusterSchema,
 		SchemaVersion: 2,
 		Timeouts: &schema.ResourceTimeout{
@@ -72,8 +108,15 @@ func resourceClusterSchema() map[string]*schema.Schema {
 					lib := libraries.NewLibraryFromInstanceState(i)
 					return schema.HashString(lib.String())
 				}
+				ss["library"].Elem.(*schema.Resource).Schema["optional"] = &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				}
 				return ss
 			})["library"]
+		s["library_install_retries"] = libraryInstallRetriesSchema()
+		s["library_status"] = libraryStatusSchema()
 
 		s["autotermination_minutes"].Default = 60
 		s["cluster_id"] = &schema.Schema{
@@ -102,6 +145,27 @@ func resourceClusterSchema() map[string]*schema.Schema {
 				return old == new
 			},
 		}
+		s["update_strategy"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  UpdateStrategyImmediate,
+			ValidateFunc: validation.StringInSlice([]string{UpdateStrategyImmediate,
+				UpdateStrategyWaitForIdle, UpdateStrategyDeferUntilTerminated}, false),
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				// imported clusters don't have it in the state
+				return old == "" && new == UpdateStrategyImmediate
+			},
+		}
+		s["update_wait_minutes"] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      defaultUpdateWaitMinutes,
+			ValidateFunc: validation.IntAtLeast(1),
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				// imported clusters don't have it in the state
+				return old == "" && new == fmt.Sprint(defaultUpdateWaitMinutes)
+			},
+		}
 		s["state"] = &schema.Schema{
 			Type:     schema.TypeString,
 			Computed: true,
@@ -155,13 +219,13 @@ func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, c *commo
 		if err = libs.Install(libraryList); err != nil {
 			return err
 		}
-		_, err := libs.WaitForLibrariesInstalled(libraries.Wait{
-			ClusterID: d.Id(),
-			Timeout:   timeout - time.Since(start),
-			IsRunning: clusterInfo.IsRunningOrResizing(),
-			IsRefresh: false,
-		})
+		wait := libraryWait(d, d.Id(), timeout-time.Since(start))
+		wait.IsRunning = clusterInfo.IsRunningOrResizing()
+		result, err := libs.WaitForLibrariesInstalled(wait)
 		if err != nil {
+			if statusErr := setLibraryStatus(d, result); statusErr != nil {
+				log.Printf("[WARN] Can't set status of libraries: %v", statusErr)
+			}
 			return err
 		}
 	}
@@ -192,6 +256,16 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, c *common.
 	if err != nil {
 		return err
 	}
+	var configured Cluster
+	common.DataToStructPointer(d, clusterSchema, &configured)
+	if configured.ApplyPolicyDefaultValues && configured.PolicyID != "" {
+		pd, err := GetPolicyDefinition(ctx, c, configured.PolicyID)
+		if err != nil {
+			log.Printf("[WARN] Can't get cluster policy %s: %v", configured.PolicyID, err)
+		} else if err = RemovePolicyDefaultValues(pd, configured, &clusterInfo); err != nil {
+			return err
+		}
+	}
 	if err = common.StructToData(clusterInfo, clusterSchema, d); err != nil {
 		return err
 	}
@@ -202,26 +276,120 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, c *common.
 	if d.Get("library.#").(int) == 0 {
 		// don't add externally added libraries, if config has no `library {}` blocks
 		// TODO: check if it still works fine with importing. Perhaps os.Setenv will do the trick
-		return nil
+		return setLibraryStatus(d, &libraries.ClusterLibraryStatuses{})
 	}
 	librariesAPI := libraries.NewLibrariesAPI(ctx, c)
-	libsClusterStatus, err := librariesAPI.WaitForLibrariesInstalled(libraries.Wait{
-		ClusterID: d.Id(),
-		Timeout:   d.Timeout(schema.TimeoutRead),
-		IsRunning: clusterInfo.IsRunningOrResizing(),
-		IsRefresh: true,
-	})
+	wait := libraryWait(d, d.Id(), d.Timeout(schema.TimeoutRead))
+	wait.IsRunning = clusterInfo.IsRunningOrResizing()
+	wait.IsRefresh = true
+	libsClusterStatus, err := librariesAPI.WaitForLibrariesInstalled(wait)
 	if err != nil {
 		return err
 	}
 	libList := libsClusterStatus.ToLibraryList()
-	return common.StructToData(libList, clusterSchema, d)
+	if err = common.StructToData(libList, clusterSchema, d); err != nil {
+		return err
+	}
+	if len(wait.Optional) > 0 {
+		// `optional` isn't returned by the API, so it's preserved from the configuration
+		libs := d.Get("library").(*schema.Set).List()
+		for _, raw := range libs {
+			lib := raw.(map[string]any)
+			lib["optional"] = wait.Optional[libraries.NewLibraryFromInstanceState(lib).String()]
+		}
+		if err = d.Set("library", libs); err != nil {
+			return err
+		}
+	}
+	return setLibraryStatus(d, libsClusterStatus)
+}
+
+func libraryInstallRetriesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeInt,
+		Optional:         true,
+		Default:          0,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+	}
+}
+
+func libraryStatusSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"library": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"status": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"messages": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// libraryWait returns settings for waiting on installation of libraries configured on the cluster
+func libraryWait(d *schema.ResourceData, clusterID string, timeout time.Duration) libraries.Wait {
+	optional := map[string]bool{}
+	for _, raw := range d.Get("library").(*schema.Set).List() {
+		lib := raw.(map[string]any)
+		if isOptional, ok := lib["optional"].(bool); ok && isOptional {
+			optional[libraries.NewLibraryFromInstanceState(lib).String()] = true
+		}
+	}
+	return libraries.Wait{
+		ClusterID: clusterID,
+		Timeout:   timeout,
+		Retries:   d.Get("library_install_retries").(int),
+		Optional:  optional,
+	}
+}
+
+// setLibraryStatus exposes installation status of every library, so that failures are easier to debug
+func setLibraryStatus(d *schema.ResourceData, cls *libraries.ClusterLibraryStatuses) error {
+	if cls == nil {
+		return nil
+	}
+	all := append([]libraries.LibraryStatus{}, cls.LibraryStatuses...)
+	statuses := []map[string]any{}
+	for _, v := range append(all, cls.Failed...) {
+		if v.IsGlobal || v.Library == nil {
+			continue
+		}
+		statuses = append(statuses, map[string]any{
+			"library":  v.Library.String(),
+			"status":   v.Status,
+			"messages": v.Messages,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i]["library"].(string) < statuses[j]["library"].(string)
+	})
+	return d.Set("library_status", statuses)
+}
+
+// nonClusterAttributes aren't part of the cluster configuration, so changing them doesn't require cluster edit
+var nonClusterAttributes = map[string]bool{
+	"library":                 true,
+	"library_install_retries": true,
+	"library_status":          true,
+	"is_pinned":               true,
+	"update_strategy":         true,
+	"update_wait_minutes":     true,
 }
 
 func hasClusterConfigChanged(d *schema.ResourceData) bool {
 	for k := range clusterSchema {
-		// TODO: create a map if we'll add more non-cluster config parameters in the future
-		if k == "library" || k == "is_pinned" {
+		if nonClusterAttributes[k] {
 			continue
 		}
 		if d.HasChange(k) {
@@ -231,6 +399,65 @@ func hasClusterConfigChanged(d *schema.ResourceData) bool {
 	return false
 }
 
+// updateWaitTimeout returns how long to wait for the update window, so that there's enough of
+// the update timeout left for editing and restarting the cluster afterwards
+func updateWaitTimeout(d *schema.ResourceData) (time.Duration, error) {
+	wait := time.Duration(d.Get("update_wait_minutes").(int)) * time.Minute
+	strategy := d.Get("update_strategy").(string)
+	if strategy == "" || strategy == UpdateStrategyImmediate {
+		return wait, nil
+	}
+	available := d.Timeout(schema.TimeoutUpdate) - DefaultProvisionTimeout
+	if available <= 0 {
+		return 0, fmt.Errorf("update timeout must be longer than %s to use %s update strategy, "+
+			"so that the cluster could be restarted after the wait, i.e. timeouts { update = \"%dm\" }",
+			DefaultProvisionTimeout, strategy, int((DefaultProvisionTimeout + wait).Minutes()))
+	}
+	if wait > available {
+		log.Printf("[WARN] Waiting for %s instead of %s, so that update timeout isn't exceeded", available, wait)
+		wait = available
+	}
+	return wait, nil
+}
+
+// waitForUpdateWindow waits until the cluster could be edited without interrupting its users
+// according to the update strategy. Edit restarts only running clusters, so other states aren't waited for.
+func waitForUpdateWindow(ctx context.Context, clusters ClustersAPI, clusterID, strategy string,
+	timeout time.Duration) error {
+	if strategy == "" || strategy == UpdateStrategyImmediate {
+		return nil
+	}
+	detector := &activityDetector{clusters: clusters}
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		info, err := clusters.Get(clusterID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		switch info.State {
+		case ClusterStateTerminated, ClusterStateTerminating:
+			return nil
+		case ClusterStateRunning, ClusterStateResizing:
+			if strategy == UpdateStrategyDeferUntilTerminated {
+				return resource.RetryableError(fmt.Errorf("cluster %s is %s", clusterID, info.State))
+			}
+			activity, err := detector.ActivitySince(info, time.Now().Add(-clusterIdlePeriod))
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+			if activity != "" {
+				return resource.RetryableError(fmt.Errorf("%s", activity))
+			}
+			return nil
+		default:
+			return resource.RetryableError(fmt.Errorf("cluster %s is %s", clusterID, info.State))
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("changes aren't applied with %s update strategy: %w", strategy, err)
+	}
+	return nil
+}
+
 func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 	clusters := NewClustersAPI(ctx, c)
 	clusterID := d.Id()
@@ -253,10 +480,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 		hasAutoscaleChanged := d.HasChange("autoscale")
 		hasOnlyResizeClusterConfigChanged := true
 		for k := range clusterSchema {
-			if k == "library" ||
-				k == "is_pinned" ||
-				k == "num_workers" ||
-				k == "autoscale" {
+			if nonClusterAttributes[k] || k == "num_workers" || k == "autoscale" {
 				continue
 			}
 			if d.HasChange(k) {
@@ -302,6 +526,15 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 				AutoScale: cluster.Autoscale,
 			})
 		} else {
+			strategy := d.Get("update_strategy").(string)
+			wait, err := updateWaitTimeout(d)
+			if err != nil {
+				return err
+			}
+			err = waitForUpdateWindow(ctx, clusters, clusterID, strategy, wait)
+			if err != nil {
+				return err
+			}
 			clusterInfo, err = clusters.Edit(cluster)
 		}
 		if err != nil {
@@ -348,9 +581,12 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 		}
 		// clusters.StartAndGetInfo() always returns a running cluster
 		// or errors out, so we just know the cluster is active.
-		err = librariesAPI.UpdateLibraries(clusterID, libsToInstall, libsToUninstall,
-			d.Timeout(schema.TimeoutUpdate))
+		result, err := librariesAPI.UpdateLibraries(libsToInstall, libsToUninstall,
+			libraryWait(d, clusterID, d.Timeout(schema.TimeoutUpdate)))
 		if err != nil {
+			if statusErr := setLibraryStatus(d, result); statusErr != nil {
+				log.Printf("[WARN] Can't set status of libraries: %v", statusErr)
+			}
 			return err
 		}
 		if clusterInfo.State == ClusterStateTerminated {
@@ -1,14 +1,19 @@
 package clusters
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/libraries"
 
 	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -359,6 +364,180 @@ func TestResourceClusterCreate_WithLibraries(t *testing.T) {
 	assert.Equal(t, "abc", d.Id())
 }
 
+func TestResourceClusterCreate_LibraryRetriesAndOptional(t *testing.T) {
+	optionalFailed := libraries.LibraryStatus{
+		Library: &libraries.Library{
+			Pypi: &libraries.PyPi{
+				Package: "optional-package",
+			},
+		},
+		Status:   "FAILED",
+		Messages: []string{"cannot resolve"},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/install",
+				ExpectedRequest: libraries.ClusterLibraryList{
+					ClusterID: "abc",
+					Libraries: []libraries.Library{
+						{
+							Whl: "dbfs://baz.whl",
+						},
+						{
+							Pypi: &libraries.PyPi{
+								Package: "optional-package",
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					LibraryStatuses: []libraries.LibraryStatus{
+						optionalFailed,
+						{
+							Library: &libraries.Library{
+								Whl: "dbfs://baz.whl",
+							},
+							Status:   "FAILED",
+							Messages: []string{"connection reset"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/uninstall",
+				ExpectedRequest: libraries.ClusterLibraryList{
+					ClusterID: "abc",
+					Libraries: []libraries.Library{
+						{
+							Pypi: &libraries.PyPi{
+								Package: "optional-package",
+							},
+						},
+						{
+							Whl: "dbfs://baz.whl",
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/restart",
+				ExpectedRequest: map[string]any{
+					"cluster_id": "abc",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/install",
+				ExpectedRequest: libraries.ClusterLibraryList{
+					ClusterID: "abc",
+					Libraries: []libraries.Library{
+						{
+							Pypi: &libraries.PyPi{
+								Package: "optional-package",
+							},
+						},
+						{
+							Whl: "dbfs://baz.whl",
+						},
+					},
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					LibraryStatuses: []libraries.LibraryStatus{
+						optionalFailed,
+						{
+							Library: &libraries.Library{
+								Whl: "dbfs://baz.whl",
+							},
+							Status: "INSTALLED",
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 1
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		library_install_retries = 1
+
+		library {
+			whl = "dbfs://baz.whl"
+		}
+
+		library {
+			pypi {
+				package = "optional-package"
+			}
+			optional = true
+		}`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{
+			"library":  "pypi:optional-package",
+			"status":   "FAILED",
+			"messages": []any{"cannot resolve"},
+		},
+		map[string]any{
+			"library":  "whl:dbfs://baz.whl",
+			"status":   "INSTALLED",
+			"messages": []any{},
+		},
+	}, d.Get("library_status"))
+	optional := map[string]bool{}
+	for _, raw := range d.Get("library").(*schema.Set).List() {
+		lib := raw.(map[string]any)
+		optional[libraries.NewLibraryFromInstanceState(lib).String()] = lib["optional"].(bool)
+	}
+	assert.Equal(t, map[string]bool{
+		"pypi:optional-package": true,
+		"whl:dbfs://baz.whl":    false,
+	}, optional)
+}
+
 func TestResourceClusterCreatePhoton(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -1683,3 +1862,250 @@ func TestRefreshOnRunningClusterWithFailedLibraryUninstallsIt(t *testing.T) {
 		ID:   "foo",
 	}.ApplyNoError(t)
 }
+
+func TestWaitForUpdateWindow_Immediate(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := waitForUpdateWindow(ctx, NewClustersAPI(ctx, client), "abc", UpdateStrategyImmediate, time.Second)
+		assert.NoError(t, err)
+	})
+}
+
+func TestWaitForUpdateWindow_Idle(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID:        "abc",
+				State:            ClusterStateRunning,
+				LastActivityTime: time.Now().Add(-time.Hour).UnixMilli(),
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.1/jobs/runs/list?active_only=true&expand_tasks=true&limit=25",
+			Response:     jobs.ListRunsResponse{},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/events",
+			Response: EventsResponse{
+				Events:     []ClusterEvent{},
+				TotalCount: 0,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := waitForUpdateWindow(ctx, NewClustersAPI(ctx, client), "abc", UpdateStrategyWaitForIdle, time.Second)
+		assert.NoError(t, err)
+	})
+}
+
+func TestWaitForUpdateWindow_Autoscaling(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				State:     ClusterStateRunning,
+			},
+		},
+		{
+			Method:       "POST",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/events",
+			Response: EventsResponse{
+				Events: []ClusterEvent{
+					{
+						ClusterID: "abc",
+						Type:      EvTypeUpsizeCompleted,
+						Timestamp: 1700000000000,
+					},
+				},
+				TotalCount: 1,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := waitForUpdateWindow(ctx, NewClustersAPI(ctx, client), "abc", UpdateStrategyWaitForIdle, time.Second)
+		assert.ErrorContains(t, err, "changes aren't applied with wait_for_idle update strategy")
+		assert.ErrorContains(t, err, "cluster abc had UPSIZE_COMPLETED event at 2023-11-14T22:13:20Z")
+	})
+}
+
+func TestWaitForUpdateWindow_ActiveCommands(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID:        "abc",
+				State:            ClusterStateRunning,
+				LastActivityTime: time.Now().UnixMilli(),
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := waitForUpdateWindow(ctx, NewClustersAPI(ctx, client), "abc", UpdateStrategyWaitForIdle, time.Second)
+		assert.ErrorContains(t, err, "cluster abc had activity at")
+	})
+}
+
+func TestWaitForUpdateWindow_ActiveJobRun(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				State:     ClusterStateRunning,
+			},
+		},
+		{
+			Method:       "POST",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/events",
+			Response: EventsResponse{
+				Events:     []ClusterEvent{},
+				TotalCount: 0,
+			},
+		},
+		{
+			// runs are listed only once, and the found run is checked afterwards
+			Method:   "GET",
+			Resource: "/api/2.1/jobs/runs/list?active_only=true&expand_tasks=true&limit=25",
+			Response: jobs.ListRunsResponse{
+				Runs: []jobs.BaseRun{
+					{
+						RunId: 41,
+						Tasks: []jobs.RunTask{
+							{
+								ClusterInstance: &jobs.ClusterInstance{ClusterId: "other"},
+							},
+						},
+					},
+					{
+						RunId: 42,
+						Tasks: []jobs.RunTask{
+							{
+								ClusterInstance: &jobs.ClusterInstance{ClusterId: "abc"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.1/jobs/runs/get?run_id=42",
+			Response: jobs.Run{
+				RunId: 42,
+				State: &jobs.RunState{
+					LifeCycleState: jobs.RunLifeCycleStateRunning,
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := waitForUpdateWindow(ctx, NewClustersAPI(ctx, client), "abc", UpdateStrategyWaitForIdle, 2*time.Second)
+		assert.ErrorContains(t, err, "cluster abc is used by active job run 42")
+	})
+}
+
+func TestActivityDetector_FinishedJobRun(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "POST",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/events",
+			Response: EventsResponse{
+				Events:     []ClusterEvent{},
+				TotalCount: 0,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/jobs/runs/get?run_id=42",
+			Response: jobs.Run{
+				RunId: 42,
+				State: &jobs.RunState{
+					LifeCycleState: jobs.RunLifeCycleStateTerminated,
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/jobs/runs/list?active_only=true&expand_tasks=true&limit=25",
+			Response: jobs.ListRunsResponse{},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		detector := &activityDetector{clusters: NewClustersAPI(ctx, client), runID: 42}
+		activity, err := detector.ActivitySince(ClusterInfo{ClusterID: "abc"}, time.Now().Add(-time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, "", activity)
+		assert.Equal(t, int64(0), detector.runID)
+	})
+}
+
+func TestUpdateWaitTimeout(t *testing.T) {
+	r := ResourceCluster()
+	r.Timeouts.Update = schema.DefaultTimeout(DefaultProvisionTimeout + defaultUpdateWaitMinutes*time.Minute)
+	d := r.Data(nil)
+	d.Set("update_strategy", UpdateStrategyWaitForIdle)
+	d.Set("update_wait_minutes", 10)
+	wait, err := updateWaitTimeout(d)
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, wait)
+
+	// the rest of the update timeout is left for the restart
+	d.Set("update_wait_minutes", 120)
+	wait, err = updateWaitTimeout(d)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultUpdateWaitMinutes*time.Minute, wait)
+
+	// the default update timeout is only enough for the restart
+	d = ResourceCluster().Data(nil)
+	d.Set("update_strategy", UpdateStrategyWaitForIdle)
+	d.Set("update_wait_minutes", 10)
+	_, err = updateWaitTimeout(d)
+	assert.EqualError(t, err, "update timeout must be longer than 30m0s to use wait_for_idle update strategy, "+
+		"so that the cluster could be restarted after the wait, i.e. timeouts { update = \"40m\" }")
+
+	d.Set("update_strategy", UpdateStrategyImmediate)
+	_, err = updateWaitTimeout(d)
+	assert.NoError(t, err)
+}
+
+func TestWaitForUpdateWindow_DeferUntilTerminated(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				State:     ClusterStateRunning,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := waitForUpdateWindow(ctx, NewClustersAPI(ctx, client), "abc",
+			UpdateStrategyDeferUntilTerminated, time.Second)
+		assert.ErrorContains(t, err, "changes aren't applied with defer_until_terminated update strategy")
+	})
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				State:     ClusterStateTerminated,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		err := waitForUpdateWindow(ctx, NewClustersAPI(ctx, client), "abc",
+			UpdateStrategyDeferUntilTerminated, time.Second)
+		assert.NoError(t, err)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/terraform-provider-databricks/common"
@@ -17,6 +18,15 @@ func ResourceLibrary() *schema.Resource {
 			Type:     schema.TypeString,
 			Required: true,
 		}
+		// library is reinstalled on any change, except for the attributes that only affect the wait
+		forceNew(m)
+		m["optional"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		}
+		m["library_install_retries"] = libraryInstallRetriesSchema()
+		m["library_status"] = libraryStatusSchema()
 		return m
 	})
 	parseId := func(id string) (string, string) {
@@ -26,6 +36,15 @@ func ResourceLibrary() *schema.Resource {
 		}
 		return split[0], split[1]
 	}
+	wait := func(d *schema.ResourceData, clusterID, key string, timeout time.Duration) libraries.Wait {
+		return libraries.Wait{
+			ClusterID: clusterID,
+			Timeout:   timeout,
+			Retries:   d.Get("library_install_retries").(int),
+			Optional:  map[string]bool{key: d.Get("optional").(bool)},
+			Libraries: map[string]bool{key: true},
+		}
+	}
 	return common.Resource{
 		Schema: s,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -44,24 +63,20 @@ func ResourceLibrary() *schema.Resource {
 			if err != nil {
 				return err
 			}
-			_, err = librariesAPI.WaitForLibrariesInstalled(libraries.Wait{
-				ClusterID: clusterID,
-				Timeout:   d.Timeout(schema.TimeoutCreate),
-				IsRunning: true,
-			})
+			w := wait(d, clusterID, lib.String(), d.Timeout(schema.TimeoutCreate))
+			w.IsRunning = true
+			cll, err := librariesAPI.WaitForLibrariesInstalled(w)
 			if err != nil {
 				return err
 			}
 			d.SetId(fmt.Sprintf("%s/%s", clusterID, lib.String()))
-			return nil
+			return setLibraryStatus(d, cll)
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			clusterID, libraryRep := parseId(d.Id())
-			cll, err := libraries.NewLibrariesAPI(ctx, c).WaitForLibrariesInstalled(libraries.Wait{
-				ClusterID: clusterID,
-				Timeout:   d.Timeout(schema.TimeoutRead),
-				IsRefresh: true,
-			})
+			w := wait(d, clusterID, libraryRep, d.Timeout(schema.TimeoutRead))
+			w.IsRefresh = true
+			cll, err := libraries.NewLibrariesAPI(ctx, c).WaitForLibrariesInstalled(w)
 			if err != nil {
 				return err
 			}
@@ -70,11 +85,19 @@ func ResourceLibrary() *schema.Resource {
 				if thisRep == libraryRep {
 					common.StructToData(v.Library, s, d)
 					d.Set("cluster_id", clusterID)
-					return nil
+					return setLibraryStatus(d, &libraries.ClusterLibraryStatuses{
+						ClusterID:       clusterID,
+						LibraryStatuses: []libraries.LibraryStatus{v},
+					})
 				}
 			}
 			return apierr.NotFound(fmt.Sprintf("cannot find %s on %s", libraryRep, clusterID))
 		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			// only `optional` and `library_install_retries` could be changed in-place, and they
+			// affect just the wait for the installation
+			return nil
+		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			clusterID, libraryRep := parseId(d.Id())
 			err := NewClustersAPI(ctx, c).Start(clusterID)
@@ -99,3 +122,17 @@ func ResourceLibrary() *schema.Resource {
 		},
 	}.ToResource()
 }
+
+// forceNew marks all configurable attributes as requiring a new resource, like common.Resource
+// does for resources without Update
+func forceNew(m map[string]*schema.Schema) {
+	for _, v := range m {
+		if v.Computed {
+			continue
+		}
+		if nested, ok := v.Elem.(*schema.Resource); ok {
+			forceNew(nested.Schema)
+		}
+		v.ForceNew = true
+	}
+}
@@ -103,3 +103,260 @@ func TestLibraryDelete(t *testing.T) {
 		ID:     "abc/whl:foo.whl",
 	}.ApplyNoError(t)
 }
+
+func TestLibraryCreate_RetriesIgnoringOtherLibraries(t *testing.T) {
+	otherFailed := libraries.LibraryStatus{
+		Library: &libraries.Library{
+			Whl: "other.whl",
+		},
+		Status:   "FAILED",
+		Messages: []string{"installed by someone else"},
+	}
+	qa.ResourceFixture{
+		Resource: ResourceLibrary(),
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				ReuseRequest: true,
+				Response: ClusterInfo{
+					State: ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/install",
+				ExpectedRequest: libraries.ClusterLibraryList{
+					Libraries: []libraries.Library{
+						{
+							Pypi: &libraries.PyPi{
+								Package: "foo",
+							},
+						},
+					},
+					ClusterID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					LibraryStatuses: []libraries.LibraryStatus{
+						otherFailed,
+						{
+							Library: &libraries.Library{
+								Pypi: &libraries.PyPi{
+									Package: "foo",
+								},
+							},
+							Status:   "FAILED",
+							Messages: []string{"connection reset"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/uninstall",
+				ExpectedRequest: libraries.ClusterLibraryList{
+					Libraries: []libraries.Library{
+						{
+							Pypi: &libraries.PyPi{
+								Package: "foo",
+							},
+						},
+					},
+					ClusterID: "abc",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/restart",
+				ExpectedRequest: map[string]any{
+					"cluster_id": "abc",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/install",
+				ExpectedRequest: libraries.ClusterLibraryList{
+					Libraries: []libraries.Library{
+						{
+							Pypi: &libraries.PyPi{
+								Package: "foo",
+							},
+						},
+					},
+					ClusterID: "abc",
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					LibraryStatuses: []libraries.LibraryStatus{
+						otherFailed,
+						{
+							Library: &libraries.Library{
+								Pypi: &libraries.PyPi{
+									Package: "foo",
+								},
+							},
+							Status: "INSTALLED",
+						},
+					},
+				},
+			},
+		},
+		Create: true,
+		HCL: `
+		cluster_id = "abc"
+		library_install_retries = 1
+		pypi {
+			package = "foo"
+		}
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                       "abc/pypi:foo",
+		"library_status.#":         1,
+		"library_status.0.library": "pypi:foo",
+		"library_status.0.status":  "INSTALLED",
+		"library_install_retries":  1,
+	})
+}
+
+func TestLibraryCreate_OptionalFailed(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceLibrary(),
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				ReuseRequest: true,
+				Response: ClusterInfo{
+					State: ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/install",
+				ExpectedRequest: libraries.ClusterLibraryList{
+					Libraries: []libraries.Library{
+						{
+							Whl: "foo.whl",
+						},
+					},
+					ClusterID: "abc",
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					LibraryStatuses: []libraries.LibraryStatus{
+						{
+							Library: &libraries.Library{
+								Whl: "foo.whl",
+							},
+							Status:   "FAILED",
+							Messages: []string{"cannot read"},
+						},
+					},
+				},
+			},
+		},
+		Create: true,
+		HCL: `
+		cluster_id = "abc"
+		whl = "foo.whl"
+		optional = true
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                          "abc/whl:foo.whl",
+		"library_status.0.status":     "FAILED",
+		"library_status.0.messages.0": "cannot read",
+	})
+}
+
+func TestLibraryCreate_Failed(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceLibrary(),
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				ReuseRequest: true,
+				Response: ClusterInfo{
+					State: ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/install",
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					LibraryStatuses: []libraries.LibraryStatus{
+						{
+							Library: &libraries.Library{
+								Whl: "foo.whl",
+							},
+							Status:   "FAILED",
+							Messages: []string{"cannot read"},
+						},
+					},
+				},
+			},
+		},
+		Create: true,
+		HCL: `
+		cluster_id = "abc"
+		whl = "foo.whl"
+		`,
+	}.ExpectError(t, "whl:foo.whl failed: cannot read")
+}
+
+func TestLibraryUpdate_OptionalInPlace(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceLibrary(),
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					LibraryStatuses: []libraries.LibraryStatus{
+						{
+							Library: &libraries.Library{
+								Whl: "foo.whl",
+							},
+							Status: "INSTALLED",
+						},
+					},
+				},
+			},
+		},
+		Update: true,
+		ID:     "abc/whl:foo.whl",
+		InstanceState: map[string]string{
+			"cluster_id": "abc",
+			"whl":        "foo.whl",
+		},
+		HCL: `
+		cluster_id = "abc"
+		whl = "foo.whl"
+		optional = true
+		library_install_retries = 2
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                      "abc/whl:foo.whl",
+		"optional":                true,
+		"library_install_retries": 2,
+		"library_status.0.status": "INSTALLED",
+	})
+}
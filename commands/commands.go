@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -33,8 +34,8 @@ type CommandsAPI struct {
 	context context.Context
 }
 
-// Execute creates a spark context and executes a command and then closes context
-// Any leading whitespace is trimmed
+// Execute executes a command in an execution context, that is reused by subsequent commands
+// on the same cluster in the same language. Any leading whitespace is trimmed
 func (a CommandsAPI) Execute(clusterID, language, commandStr string) common.CommandResults {
 	// this is the place, where API version propagation through context looks strange
 	ctx := context.WithValue(a.context, common.Api, common.API_2_0)
@@ -53,57 +54,98 @@ func (a CommandsAPI) Execute(clusterID, language, commandStr string) common.Comm
 	}
 	commandStr = TrimLeadingWhitespace(commandStr)
 	log.Printf("[INFO] Executing %s command on %s:\n%s", language, clusterID, commandStr)
-	context, err := a.createContext(language, clusterID)
+	key := contextKey{a.client, clusterID, language}
+	command, err := a.executeInPooledContext(key, commandStr)
 	if err != nil {
 		return common.CommandResults{
 			ResultType: "error",
 			Summary:    err.Error(),
 		}
 	}
-	err = a.waitForContextReady(context, clusterID)
-	if err != nil {
+	if command.Results == nil {
+		log.Printf("[ERROR] Command has no results: %#v", command)
 		return common.CommandResults{
 			ResultType: "error",
-			Summary:    err.Error(),
+			Summary:    "Command has no results",
 		}
 	}
-	commandID, err := a.createCommand(context, clusterID, language, commandStr)
-	if err != nil {
-		return common.CommandResults{
-			ResultType: "error",
-			Summary:    err.Error(),
+	return *command.Results
+}
+
+// commandNotAcceptedError means that the command wasn't started, e.g. because the execution context is gone
+// after cluster restart, so it's safe to run the command in another context
+type commandNotAcceptedError struct {
+	err error
+}
+
+func (e commandNotAcceptedError) Error() string {
+	return e.err.Error()
+}
+
+func (e commandNotAcceptedError) Unwrap() error {
+	return e.err
+}
+
+// executeInPooledContext runs the command in an idle execution context, if there is one, or in a new one.
+// Pooled contexts might be gone, e.g. after cluster restart, so the command is retried in a new context,
+// but only if it wasn't accepted, as commands aren't idempotent.
+func (a CommandsAPI) executeInPooledContext(key contextKey, commandStr string) (Command, error) {
+	if contextIdleTimeout > 0 {
+		if contextID := executionContexts.acquire(key); contextID != "" {
+			log.Printf("[DEBUG] Reusing execution context %s on %s", contextID, key.clusterID)
+			command, err := a.executeInContext(key, contextID, commandStr)
+			if !errors.As(err, &commandNotAcceptedError{}) {
+				return command, err
+			}
+			log.Printf("[WARN] Failed to execute command in pooled context %s, retrying in a new one: %v",
+				contextID, err)
 		}
 	}
-	// TODO: merge getCommand and waitForCommandFinished to "waitForCommandResults"
-	err = a.waitForCommandFinished(commandID, context, clusterID)
+	contextID, err := a.createContext(key.language, key.clusterID)
 	if err != nil {
-		return common.CommandResults{
-			ResultType: "error",
-			Summary:    err.Error(),
-		}
+		return Command{}, err
 	}
-	command, err := a.getCommand(commandID, context, clusterID)
+	err = a.waitForContextReady(contextID, key.clusterID)
 	if err != nil {
-		return common.CommandResults{
-			ResultType: "error",
-			Summary:    err.Error(),
-		}
+		return Command{}, err
 	}
-	err = a.deleteContext(context, clusterID)
+	command, err := a.executeInContext(key, contextID, commandStr)
 	if err != nil {
-		return common.CommandResults{
-			ResultType: "error",
-			Summary:    err.Error(),
-		}
+		return command, err
 	}
-	if command.Results == nil {
-		log.Printf("[ERROR] Command has no results: %#v", command)
-		return common.CommandResults{
-			ResultType: "error",
-			Summary:    "Command has no results",
+	if contextIdleTimeout <= 0 {
+		err = a.deleteContext(contextID, key.clusterID)
+	}
+	return command, err
+}
+
+// executeInContext runs the command and returns the context to the pool, if it could be reused.
+// Contexts that failed to run a command are destroyed.
+func (a CommandsAPI) executeInContext(key contextKey, contextID, commandStr string) (Command, error) {
+	command, err := a.runCommand(contextID, key.clusterID, key.language, commandStr)
+	if err != nil {
+		if deleteErr := a.deleteContext(contextID, key.clusterID); deleteErr != nil {
+			log.Printf("[WARN] Can't destroy execution context %s on %s: %v", contextID, key.clusterID, deleteErr)
 		}
+		return command, err
 	}
-	return *command.Results
+	if contextIdleTimeout > 0 {
+		executionContexts.release(key, contextID)
+	}
+	return command, nil
+}
+
+func (a CommandsAPI) runCommand(contextID, clusterID, language, commandStr string) (Command, error) {
+	commandID, err := a.createCommand(contextID, clusterID, language, commandStr)
+	if err != nil {
+		return Command{}, commandNotAcceptedError{err}
+	}
+	// TODO: merge getCommand and waitForCommandFinished to "waitForCommandResults"
+	err = a.waitForCommandFinished(commandID, contextID, clusterID)
+	if err != nil {
+		return Command{}, err
+	}
+	return a.getCommand(commandID, contextID, clusterID)
 }
 
 type genericCommandRequest struct {
@@ -308,6 +308,14 @@ func TestCommandsAPIExecute_FailToCreateCommand(t *testing.T) {
 				Message: "Does not compute",
 			},
 		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/destroy",
+			ExpectedRequest: genericCommandRequest{
+				ClusterID: "abc",
+				ContextID: "abc",
+			},
+		},
 	}, func(ctx context.Context, client *common.DatabricksClient) {
 		commands := NewCommandsAPI(ctx, client)
 		cr := commands.Execute("abc", "cobol", "Hello?")
@@ -353,6 +361,14 @@ func TestCommandsAPIExecute_FailToWaitForCommand(t *testing.T) {
 				Message: "Does not compute",
 			},
 		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/destroy",
+			ExpectedRequest: genericCommandRequest{
+				ClusterID: "abc",
+				ContextID: "abc",
+			},
+		},
 	}, func(ctx context.Context, client *common.DatabricksClient) {
 		commands := NewCommandsAPI(ctx, client)
 		cr := commands.Execute("abc", "cobol", "Hello?")
@@ -405,6 +421,14 @@ func TestCommandsAPIExecute_FailToGetCommand(t *testing.T) {
 				Message: "Does not compute",
 			},
 		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/destroy",
+			ExpectedRequest: genericCommandRequest{
+				ClusterID: "abc",
+				ContextID: "abc",
+			},
+		},
 	}, func(ctx context.Context, client *common.DatabricksClient) {
 		commands := NewCommandsAPI(ctx, client)
 		cr := commands.Execute("abc", "cobol", "Hello?")
@@ -413,6 +437,7 @@ func TestCommandsAPIExecute_FailToGetCommand(t *testing.T) {
 }
 
 func TestCommandsAPIExecute_FailToDeleteContext(t *testing.T) {
+	withoutContextPool(t)
 	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
 		{
 			Method:   "GET",
@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"context"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+)
+
+// contextIdleTimeout is how long an unused execution context is kept for reuse before it's destroyed.
+// Zero timeout disables pooling, so that every command gets a new execution context.
+var contextIdleTimeout = idleTimeoutFromEnv("DATABRICKS_COMMAND_CONTEXT_IDLE_TIMEOUT", 2*time.Minute)
+
+func idleTimeoutFromEnv(name string, defaultValue time.Duration) time.Duration {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return defaultValue
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("[WARN] Can't parse %s=%s, using %s: %v", name, value, defaultValue, err)
+		return defaultValue
+	}
+	return timeout
+}
+
+// contextKey identifies execution contexts that could be used interchangeably. Contexts keep the state of
+// previous commands, so they are reused only by the same client, e.g. not shared between provider aliases
+// that authenticate as different principals.
+type contextKey struct {
+	client    *common.DatabricksClient
+	clusterID string
+	language  string
+}
+
+type pooledContext struct {
+	id       string
+	lastUsed time.Time
+}
+
+// contextPool keeps idle execution contexts, so that consecutive commands on the same cluster
+// don't pay the start-up latency of a new context. Every context is used by one command at a time.
+type contextPool struct {
+	mu    sync.Mutex
+	idle  map[contextKey][]pooledContext
+	timer *time.Timer
+}
+
+var executionContexts = &contextPool{
+	idle: map[contextKey][]pooledContext{},
+}
+
+// acquire returns the most recently used idle context or an empty string, if there are none
+func (p *contextPool) acquire(key contextKey) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	contexts := p.idle[key]
+	if len(contexts) == 0 {
+		return ""
+	}
+	last := contexts[len(contexts)-1]
+	p.idle[key] = contexts[:len(contexts)-1]
+	return last.id
+}
+
+// release puts the context back to the pool and schedules its eviction
+func (p *contextPool) release(key contextKey, contextID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.idle[key] = append(p.idle[key], pooledContext{
+		id:       contextID,
+		lastUsed: time.Now(),
+	})
+	if p.timer == nil {
+		p.timer = time.AfterFunc(contextIdleTimeout, p.evictIdle)
+	}
+}
+
+// take removes contexts that are idle since the given time from the pool
+func (p *contextPool) take(idleSince time.Time) map[contextKey][]pooledContext {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	taken := map[contextKey][]pooledContext{}
+	for key, contexts := range p.idle {
+		remaining := []pooledContext{}
+		for _, pc := range contexts {
+			if pc.lastUsed.After(idleSince) {
+				remaining = append(remaining, pc)
+				continue
+			}
+			taken[key] = append(taken[key], pc)
+		}
+		if len(remaining) == 0 {
+			delete(p.idle, key)
+			continue
+		}
+		p.idle[key] = remaining
+	}
+	return taken
+}
+
+func (p *contextPool) evictIdle() {
+	evicted := p.take(time.Now().Add(-contextIdleTimeout))
+	p.mu.Lock()
+	p.timer = nil
+	if len(p.idle) > 0 {
+		p.timer = time.AfterFunc(contextIdleTimeout, p.evictIdle)
+	}
+	p.mu.Unlock()
+	destroyContexts(evicted)
+}
+
+func destroyContexts(contexts map[contextKey][]pooledContext) {
+	for key, pooled := range contexts {
+		for _, pc := range pooled {
+			// contexts outlive requests of Terraform, so their context.Context can't be used
+			a := NewCommandsAPI(context.Background(), key.client)
+			log.Printf("[DEBUG] Destroying idle execution context %s on %s", pc.id, key.clusterID)
+			if err := a.deleteContext(pc.id, key.clusterID); err != nil {
+				log.Printf("[WARN] Can't destroy execution context %s on %s: %v", pc.id, key.clusterID, err)
+			}
+		}
+	}
+}
+
+// DestroyIdleContexts destroys all pooled execution contexts. It should be called before the process exits,
+// so that contexts don't count towards the limit of execution contexts on clusters. It's best-effort,
+// as the process might be killed before, so the idle timer is the primary way of evicting contexts.
+func DestroyIdleContexts() {
+	destroyContexts(executionContexts.take(time.Now()))
+}
@@ -0,0 +1,272 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/terraform-provider-databricks/clusters"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withoutContextPool(t *testing.T) {
+	timeout := contextIdleTimeout
+	contextIdleTimeout = 0
+	t.Cleanup(func() {
+		contextIdleTimeout = timeout
+	})
+}
+
+// withEmptyContextPool isolates the test from contexts, that were pooled by other tests
+func withEmptyContextPool(t *testing.T) {
+	pool := executionContexts
+	executionContexts = &contextPool{
+		idle: map[contextKey][]pooledContext{},
+	}
+	t.Cleanup(func() {
+		executionContexts = pool
+	})
+}
+
+func finishedCommandFixtures(contextID string) []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:       "POST",
+			ReuseRequest: true,
+			Resource:     "/api/1.2/commands/execute",
+			ExpectedRequest: genericCommandRequest{
+				Language:  "python",
+				ClusterID: "abc",
+				ContextID: contextID,
+				Command:   "print(1)\n",
+			},
+			Response: Command{
+				ID: "234",
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/1.2/commands/status?clusterId=abc&commandId=234&contextId=" + contextID,
+			Response: Command{
+				Status: "Finished",
+				Results: &common.CommandResults{
+					ResultType: "text",
+					Data:       "1",
+				},
+			},
+		},
+	}
+}
+
+func TestExecuteReusesContext(t *testing.T) {
+	withEmptyContextPool(t)
+	fixtures := []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+			Response: clusters.ClusterInfo{
+				State: clusters.ClusterStateRunning,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/create",
+			Response: Command{
+				ID: "123",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/1.2/contexts/status?clusterId=abc&contextId=123",
+			Response: Command{
+				Status: "Running",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/destroy",
+			ExpectedRequest: genericCommandRequest{
+				ClusterID: "abc",
+				ContextID: "123",
+			},
+		},
+	}
+	qa.HTTPFixturesApply(t, append(fixtures, finishedCommandFixtures("123")...),
+		func(ctx context.Context, client *common.DatabricksClient) {
+			commands := NewCommandsAPI(ctx, client)
+			for i := 0; i < 3; i++ {
+				cr := commands.Execute("abc", "python", "print(1)")
+				require.NoError(t, cr.Err())
+				assert.Equal(t, "1", cr.Text())
+			}
+			DestroyIdleContexts()
+			assert.Len(t, executionContexts.take(time.Now()), 0)
+		})
+}
+
+func TestExecuteRetriesInNewContext(t *testing.T) {
+	withEmptyContextPool(t)
+	fixtures := []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+			Response: clusters.ClusterInfo{
+				State: clusters.ClusterStateRunning,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/commands/execute",
+			ExpectedRequest: genericCommandRequest{
+				Language:  "python",
+				ClusterID: "abc",
+				ContextID: "stale",
+				Command:   "print(1)\n",
+			},
+			Status: 400,
+			Response: apierr.APIError{
+				ErrorCode: "INVALID_PARAMETER_VALUE",
+				Message:   "ContextNotFound: stale",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/destroy",
+			ExpectedRequest: genericCommandRequest{
+				ClusterID: "abc",
+				ContextID: "stale",
+			},
+			Status: 400,
+			Response: apierr.APIError{
+				ErrorCode: "INVALID_PARAMETER_VALUE",
+				Message:   "ContextNotFound: stale",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/create",
+			Response: Command{
+				ID: "123",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/1.2/contexts/status?clusterId=abc&contextId=123",
+			Response: Command{
+				Status: "Running",
+			},
+		},
+	}
+	qa.HTTPFixturesApply(t, append(fixtures, finishedCommandFixtures("123")...),
+		func(ctx context.Context, client *common.DatabricksClient) {
+			key := contextKey{client, "abc", "python"}
+			executionContexts.release(key, "stale")
+
+			cr := NewCommandsAPI(ctx, client).Execute("abc", "python", "print(1)")
+			require.NoError(t, cr.Err())
+			assert.Equal(t, "1", cr.Text())
+			assert.Equal(t, "123", executionContexts.acquire(key))
+		})
+}
+
+func TestExecuteDoesNotRetryAcceptedCommand(t *testing.T) {
+	withEmptyContextPool(t)
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+			Response: clusters.ClusterInfo{
+				State: clusters.ClusterStateRunning,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/commands/execute",
+			ExpectedRequest: genericCommandRequest{
+				Language:  "python",
+				ClusterID: "abc",
+				ContextID: "123",
+				Command:   "print(1)\n",
+			},
+			Response: Command{
+				ID: "234",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/1.2/commands/status?clusterId=abc&commandId=234&contextId=123",
+			Response: Command{
+				Status: "Cancelled",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/destroy",
+			ExpectedRequest: genericCommandRequest{
+				ClusterID: "abc",
+				ContextID: "123",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		key := contextKey{client, "abc", "python"}
+		executionContexts.release(key, "123")
+
+		cr := NewCommandsAPI(ctx, client).Execute("abc", "python", "print(1)")
+		assert.EqualError(t, cr.Err(), "Command cannot finish: Cancelled")
+		assert.Equal(t, "", executionContexts.acquire(key))
+	})
+}
+
+func TestContextPoolIsolatesClients(t *testing.T) {
+	withEmptyContextPool(t)
+	first := &common.DatabricksClient{}
+	second := &common.DatabricksClient{}
+	executionContexts.release(contextKey{first, "abc", "python"}, "123")
+	assert.Equal(t, "", executionContexts.acquire(contextKey{second, "abc", "python"}))
+	assert.Equal(t, "123", executionContexts.acquire(contextKey{first, "abc", "python"}))
+}
+
+func TestContextPoolEvictsIdleContexts(t *testing.T) {
+	withEmptyContextPool(t)
+	timeout := contextIdleTimeout
+	contextIdleTimeout = 10 * time.Millisecond
+	defer func() {
+		contextIdleTimeout = timeout
+	}()
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/1.2/contexts/destroy",
+			ExpectedRequest: genericCommandRequest{
+				ClusterID: "abc",
+				ContextID: "123",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		key := contextKey{client, "abc", "python"}
+		executionContexts.release(key, "123")
+		assert.Eventually(t, func() bool {
+			executionContexts.mu.Lock()
+			defer executionContexts.mu.Unlock()
+			return len(executionContexts.idle) == 0 && executionContexts.timer == nil
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestIdleTimeoutFromEnv(t *testing.T) {
+	t.Setenv("TEST_IDLE_TIMEOUT", "30s")
+	assert.Equal(t, 30*time.Second, idleTimeoutFromEnv("TEST_IDLE_TIMEOUT", time.Minute))
+	t.Setenv("TEST_IDLE_TIMEOUT", "0")
+	assert.Equal(t, time.Duration(0), idleTimeoutFromEnv("TEST_IDLE_TIMEOUT", time.Minute))
+	t.Setenv("TEST_IDLE_TIMEOUT", "abc")
+	assert.Equal(t, time.Minute, idleTimeoutFromEnv("TEST_IDLE_TIMEOUT", time.Minute))
+	assert.Equal(t, time.Minute, idleTimeoutFromEnv("TEST_MISSING_IDLE_TIMEOUT", time.Minute))
+}
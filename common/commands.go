@@ -2,8 +2,10 @@ package common
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"html"
+	"reflect"
 	"regexp"
 	"strings"
 )
@@ -119,6 +121,230 @@ func (cr *CommandResults) Error() string {
 	return summary
 }
 
+// ResultColumn describes a column of `table` results
+type ResultColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+}
+
+// Columns returns schema of `table` results
+func (cr *CommandResults) Columns() []ResultColumn {
+	if cr.ResultType != "table" {
+		return nil
+	}
+	schema, ok := cr.Schema.([]any)
+	if !ok {
+		return nil
+	}
+	columns := []ResultColumn{}
+	for _, v := range schema {
+		field, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		name, _ := field["name"].(string)
+		columns = append(columns, ResultColumn{
+			Name: name,
+			Type: columnType(field["type"]),
+		})
+	}
+	return columns
+}
+
+// columnType returns Spark SQL type of a column, that is either a JSON-encoded
+// string, like "\"string\"", or a JSON object for complex types
+func columnType(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		var unquoted string
+		if err := json.Unmarshal([]byte(t), &unquoted); err == nil {
+			return unquoted
+		}
+		return t
+	default:
+		raw, _ := json.Marshal(t)
+		return string(raw)
+	}
+}
+
+// Rows returns rows of `table` results
+func (cr *CommandResults) Rows() []ResultRow {
+	if cr.ResultType != "table" {
+		return nil
+	}
+	data, ok := cr.Data.([]any)
+	if !ok {
+		return nil
+	}
+	columns := cr.Columns()
+	rows := []ResultRow{}
+	for _, v := range data {
+		values, ok := v.([]any)
+		if !ok {
+			continue
+		}
+		rows = append(rows, ResultRow{
+			columns: columns,
+			values:  values,
+		})
+	}
+	return rows
+}
+
+// DecodeRows decodes `table` results into a pointer to a slice of structs or maps,
+// where JSON field names are matched with column names
+func (cr *CommandResults) DecodeRows(dest any) error {
+	if cr.Failed() {
+		return cr.Err()
+	}
+	if cr.ResultType != "table" {
+		return fmt.Errorf("expected table results, got %s", cr.ResultType)
+	}
+	columns := cr.Columns()
+	if len(columns) == 0 {
+		return fmt.Errorf("table results have no schema")
+	}
+	if err := checkDecodedColumns(dest, columns); err != nil {
+		return err
+	}
+	records := []map[string]any{}
+	for i, row := range cr.Rows() {
+		if len(row.values) != len(columns) {
+			return fmt.Errorf("row %d has %d values, but there are %d columns",
+				i, len(row.values), len(columns))
+		}
+		record := map[string]any{}
+		for j, column := range columns {
+			record[column.Name] = row.values[j]
+		}
+		records = append(records, record)
+	}
+	raw, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, dest)
+}
+
+// checkDecodedColumns returns error if there's no column for a JSON field of the struct,
+// because it would silently stay empty otherwise. Fields with `omitempty` are optional
+func checkDecodedColumns(dest any, columns []ResultColumn) error {
+	t := reflect.TypeOf(dest)
+	for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	names := map[string]bool{}
+	for _, column := range columns {
+		names[column.Name] = true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := strings.Split(field.Tag.Get("json"), ",")
+		name := tag[0]
+		if name == "-" || len(tag) > 1 && tag[1] == "omitempty" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if !names[name] {
+			return fmt.Errorf("table results have no %s column", name)
+		}
+	}
+	return nil
+}
+
+// ResultRow is a single row of `table` results
+type ResultRow struct {
+	columns []ResultColumn
+	values  []any
+}
+
+// Value returns value of the column by name, or false if there's no such column
+func (r ResultRow) Value(column string) (any, bool) {
+	for i, c := range r.columns {
+		if c.Name == column && i < len(r.values) {
+			return r.values[i], true
+		}
+	}
+	return nil, false
+}
+
+// String returns value of the column as string, or an empty string for nulls
+func (r ResultRow) String(column string) string {
+	v, _ := r.Value(column)
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+// Int64 returns value of the column as integer, or zero for nulls and non-numeric values
+func (r ResultRow) Int64(column string) int64 {
+	v, _ := r.Value(column)
+	i, _ := toInt64(v)
+	return i
+}
+
+// Float64 returns value of the column as float, or zero for nulls and non-numeric values
+func (r ResultRow) Float64(column string) float64 {
+	v, _ := r.Value(column)
+	switch x := v.(type) {
+	case float64:
+		return x
+	case string:
+		var f float64
+		if err := json.Unmarshal([]byte(x), &f); err == nil {
+			return f
+		}
+	}
+	i, _ := toInt64(v)
+	return float64(i)
+}
+
+// Bool returns value of the column as boolean, or false for nulls
+func (r ResultRow) Bool(column string) bool {
+	v, _ := r.Value(column)
+	switch x := v.(type) {
+	case bool:
+		return x
+	case string:
+		return strings.EqualFold(x, "true")
+	}
+	return false
+}
+
+// toInt64 converts numbers, that are decoded from JSON as float64 or are
+// serialized as strings, e.g. for BIGINT and DECIMAL columns
+func toInt64(v any) (int64, bool) {
+	switch x := v.(type) {
+	case int:
+		return int64(x), true
+	case int64:
+		return x, true
+	case float64:
+		return int64(x), true
+	case string:
+		var i int64
+		if err := json.Unmarshal([]byte(x), &i); err == nil {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
 // Scan scans for results
 func (cr *CommandResults) Scan(dest ...any) bool {
 	if cr.ResultType != "table" {
@@ -132,11 +358,12 @@ func (cr *CommandResults) Scan(dest ...any) bool {
 			for i := range dest {
 				switch d := dest[i].(type) {
 				case *string:
-					*d = cols[i].(string)
+					*d, _ = cols[i].(string)
 				case *int:
-					*d = cols[i].(int)
+					v, _ := toInt64(cols[i])
+					*d = int(v)
 				case *bool:
-					*d = cols[i].(bool)
+					*d, _ = cols[i].(bool)
 				}
 			}
 			cr.pos++
@@ -77,3 +77,117 @@ func TestCommandResults_Scan(t *testing.T) {
 
 	assert.False(t, cr.Scan(&a, &b, &c))
 }
+
+func tableResults() CommandResults {
+	return CommandResults{
+		ResultType: "table",
+		Schema: []any{
+			map[string]any{"name": "name", "type": `"string"`},
+			map[string]any{"name": "size", "type": `"long"`},
+			map[string]any{"name": "ratio", "type": `"double"`},
+			map[string]any{"name": "enabled", "type": `"boolean"`},
+			map[string]any{"name": "tags", "type": map[string]any{
+				"type": "map", "keyType": "string", "valueType": "string"}},
+		},
+		Data: []any{
+			[]any{"foo", float64(10), 0.5, true, map[string]any{"a": "b"}},
+			[]any{"bar", "12345678901", "1.5", "false", nil},
+			[]any{nil, nil, nil, nil, nil},
+		},
+	}
+}
+
+func TestCommandResults_Columns(t *testing.T) {
+	cr := tableResults()
+	assert.Equal(t, []ResultColumn{
+		{Name: "name", Type: "string"},
+		{Name: "size", Type: "long"},
+		{Name: "ratio", Type: "double"},
+		{Name: "enabled", Type: "boolean"},
+		{Name: "tags", Type: `{"keyType":"string","type":"map","valueType":"string"}`},
+	}, cr.Columns())
+
+	text := CommandResults{ResultType: "text", Data: "done"}
+	assert.Nil(t, text.Columns())
+	assert.Nil(t, text.Rows())
+}
+
+func TestCommandResults_Rows(t *testing.T) {
+	cr := tableResults()
+	rows := cr.Rows()
+	assert.Len(t, rows, 3)
+
+	assert.Equal(t, "foo", rows[0].String("name"))
+	assert.Equal(t, int64(10), rows[0].Int64("size"))
+	assert.Equal(t, 0.5, rows[0].Float64("ratio"))
+	assert.True(t, rows[0].Bool("enabled"))
+
+	assert.Equal(t, "bar", rows[1].String("name"))
+	assert.Equal(t, int64(12345678901), rows[1].Int64("size"))
+	assert.Equal(t, 1.5, rows[1].Float64("ratio"))
+	assert.False(t, rows[1].Bool("enabled"))
+
+	assert.Equal(t, "", rows[2].String("name"))
+	assert.Equal(t, int64(0), rows[2].Int64("size"))
+	assert.Equal(t, 0.0, rows[2].Float64("ratio"))
+
+	_, ok := rows[0].Value("missing")
+	assert.False(t, ok)
+	assert.Equal(t, "", rows[0].String("missing"))
+}
+
+func TestCommandResults_DecodeRows(t *testing.T) {
+	type record struct {
+		Name string            `json:"name"`
+		Tags map[string]string `json:"tags"`
+	}
+	cr := tableResults()
+	var records []record
+	err := cr.DecodeRows(&records)
+	assert.NoError(t, err)
+	assert.Equal(t, []record{
+		{Name: "foo", Tags: map[string]string{"a": "b"}},
+		{Name: "bar"},
+		{},
+	}, records)
+}
+
+func TestCommandResults_DecodeRowsErrors(t *testing.T) {
+	var records []map[string]any
+
+	cr := CommandResults{ResultType: "error", Summary: "Things are broken"}
+	assert.EqualError(t, cr.DecodeRows(&records), "Things are broken")
+
+	cr = CommandResults{ResultType: "text", Data: "done"}
+	assert.EqualError(t, cr.DecodeRows(&records), "expected table results, got text")
+
+	cr = CommandResults{ResultType: "table", Data: []any{[]any{"a"}}}
+	assert.EqualError(t, cr.DecodeRows(&records), "table results have no schema")
+
+	cr = tableResults()
+	cr.Data = []any{[]any{"a"}}
+	assert.EqualError(t, cr.DecodeRows(&records), "row 0 has 1 values, but there are 5 columns")
+
+	type renamed struct {
+		Name     string `json:"name"`
+		FullName string `json:"full_name"`
+		Comment  string `json:"comment,omitempty"`
+	}
+	var renamedRecords []renamed
+	cr = tableResults()
+	assert.EqualError(t, cr.DecodeRows(&renamedRecords), "table results have no full_name column")
+}
+
+func TestCommandResults_ScanJSONNumbers(t *testing.T) {
+	cr := CommandResults{
+		ResultType: "table",
+		Data: []any{
+			[]any{nil, float64(3)},
+		},
+	}
+	a := "x"
+	b := 0
+	assert.True(t, cr.Scan(&a, &b))
+	assert.Equal(t, "", a)
+	assert.Equal(t, 3, b)
+}
@@ -25,6 +25,10 @@ type Resource struct {
 	Schema         map[string]*schema.Schema
 	SchemaVersion  int
 	Timeouts       *schema.ResourceTimeout
+
+	// CustomizeDiffWithClient is for best-effort checks that require API calls, it's called after
+	// CustomizeDiff. Errors of API calls should be ignored, as client may be not fully configured yet
+	CustomizeDiffWithClient func(ctx context.Context, d *schema.ResourceDiff, c *DatabricksClient) error
 }
 
 func nicerError(ctx context.Context, err error, action string) error {
@@ -55,10 +59,10 @@ func recoverable(cb func(
 }
 
 func (r Resource) saferCustomizeDiff() schema.CustomizeDiffFunc {
-	if r.CustomizeDiff == nil {
+	if r.CustomizeDiff == nil && r.CustomizeDiffWithClient == nil {
 		return nil
 	}
-	return func(ctx context.Context, rd *schema.ResourceDiff, _ any) (err error) {
+	return func(ctx context.Context, rd *schema.ResourceDiff, m any) (err error) {
 		defer func() {
 			// this is deliberate decision to convert a panic into error,
 			// so that any unforeseen bug would we visible to end-user
@@ -71,8 +75,15 @@ func (r Resource) saferCustomizeDiff() schema.CustomizeDiffFunc {
 		}()
 		// we don't propagate instance of SDK client to the diff function, because
 		// authentication is not deterministic at this stage with the recent Terraform
-		// versions. Diff customization must be limited to hermetic checks only anyway.
-		err = r.CustomizeDiff(ctx, rd)
+		// versions. Diff customization must be limited to hermetic checks only anyway,
+		// except best-effort checks from CustomizeDiffWithClient.
+		if r.CustomizeDiff != nil {
+			err = r.CustomizeDiff(ctx, rd)
+		}
+		c, ok := m.(*DatabricksClient)
+		if err == nil && ok && c != nil && r.CustomizeDiffWithClient != nil {
+			err = r.CustomizeDiffWithClient(ctx, rd, c)
+		}
 		if err != nil {
 			err = nicerError(ctx, err, "customize diff for")
 		}
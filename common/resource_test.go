@@ -179,6 +179,30 @@ func TestCustomizeDiffRobustness(t *testing.T) {
 	assert.EqualError(t, err, "cannot customize diff for sample: panic: oops")
 }
 
+func TestCustomizeDiffWithClient(t *testing.T) {
+	called := false
+	r := Resource{
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff) error {
+			return nil
+		},
+		CustomizeDiffWithClient: func(ctx context.Context, d *schema.ResourceDiff, c *DatabricksClient) error {
+			called = true
+			return fmt.Errorf("nope")
+		},
+	}.ToResource()
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, ResourceName, "sample")
+
+	err := r.CustomizeDiff(ctx, nil, nil)
+	assert.NoError(t, err)
+	assert.False(t, called, "must not be called without client")
+
+	err = r.CustomizeDiff(ctx, nil, &DatabricksClient{})
+	assert.EqualError(t, err, "cannot customize diff for sample: nope")
+	assert.True(t, called)
+}
+
 func TestEqualFoldDiffSuppress(t *testing.T) {
 	assert.True(t, EqualFoldDiffSuppress("k", "A", "a", nil))
 	assert.False(t, EqualFoldDiffSuppress("k", "A", "A2", nil))
@@ -0,0 +1,305 @@
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const checkpointFileName = "exporter-checkpoint.json"
+
+// checkpointResource describes resource that was already read from Databricks
+type checkpointResource struct {
+	Resource string `json:"resource"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Mode     string `json:"mode"`
+	// flattened Terraform state of the resource
+	Attributes map[string]string `json:"attributes"`
+}
+
+// checkpointPending describes resource that was emitted, but wasn't imported yet
+type checkpointPending struct {
+	Resource  string `json:"resource"`
+	ID        string `json:"id,omitempty"`
+	Attribute string `json:"attribute,omitempty"`
+	Value     string `json:"value,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Mode      string `json:"mode,omitempty"`
+}
+
+// checkpointFilters are options of the export, that have to be the same when it's resumed
+type checkpointFilters struct {
+	Services     string `json:"services"`
+	Listing      string `json:"listing"`
+	Match        string `json:"match,omitempty"`
+	IncludeRegex string `json:"include_regex,omitempty"`
+	ExcludeRegex string `json:"exclude_regex,omitempty"`
+	Owners       string `json:"owners,omitempty"`
+	NoOwner      bool   `json:"include_unknown_owners,omitempty"`
+	Tags         string `json:"tags,omitempty"`
+}
+
+type checkpoint struct {
+	checkpointFilters
+	Resources []checkpointResource `json:"resources"`
+	Pending   []checkpointPending  `json:"pending"`
+}
+
+func (ic *importContext) checkpointFile() string {
+	return fmt.Sprintf("%s/%s", ic.Directory, checkpointFileName)
+}
+
+func (ic *importContext) checkpointFilters() checkpointFilters {
+	return checkpointFilters{
+		Services:     ic.services,
+		Listing:      ic.listing,
+		Match:        ic.match,
+		IncludeRegex: ic.includeRegexStr,
+		ExcludeRegex: ic.excludeRegexStr,
+		Owners:       ic.ownersStr,
+		NoOwner:      ic.unknownOwners,
+		Tags:         ic.tagsStr,
+	}
+}
+
+// checkResumeFilters returns error if the checkpoint was created with other filters, because
+// imported and pending resources of the checkpoint could be not matching the current ones
+func (ic *importContext) checkResumeFilters(cp *checkpoint) error {
+	if cp.checkpointFilters == ic.checkpointFilters() {
+		return nil
+	}
+	saved, _ := json.Marshal(cp.checkpointFilters)
+	current, _ := json.Marshal(ic.checkpointFilters())
+	return fmt.Errorf("checkpoint %s was created with filters %s, but resuming with %s. "+
+		"Use the same filters or remove the checkpoint file", ic.checkpointFile(), saved, current)
+}
+
+func (ic *importContext) addPending(r *resource) {
+	ic.pendingMutex.Lock()
+	defer ic.pendingMutex.Unlock()
+	if ic.pending == nil {
+		ic.pending = map[string]*resource{}
+	}
+	ic.pending[r.String()] = &resource{
+		Resource:  r.Resource,
+		ID:        r.ID,
+		Attribute: r.Attribute,
+		Value:     r.Value,
+		Name:      r.Name,
+		Mode:      r.Mode,
+	}
+}
+
+func (ic *importContext) removePending(key string) {
+	ic.pendingMutex.Lock()
+	defer ic.pendingMutex.Unlock()
+	delete(ic.pending, key)
+}
+
+// buildCheckpoint takes a snapshot of imported & pending resources. Every emitted resource is
+// either pending or imported, so a resource that is imported concurrently may be in both lists
+func (ic *importContext) buildCheckpoint() checkpoint {
+	cp := checkpoint{
+		checkpointFilters: ic.checkpointFilters(),
+		Resources:         []checkpointResource{},
+		Pending:           []checkpointPending{},
+	}
+	ic.pendingMutex.Lock()
+	for _, r := range ic.pending {
+		cp.Pending = append(cp.Pending, checkpointPending{
+			Resource:  r.Resource,
+			ID:        r.ID,
+			Attribute: r.Attribute,
+			Value:     r.Value,
+			Name:      r.Name,
+			Mode:      r.Mode,
+		})
+	}
+	ic.pendingMutex.Unlock()
+	sort.Slice(cp.Pending, func(i, j int) bool {
+		a, b := cp.Pending[i], cp.Pending[j]
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		return a.ID+a.Value < b.ID+b.Value
+	})
+	for _, r := range ic.Scope.Sorted() {
+		state := r.Data.State()
+		if state == nil {
+			continue
+		}
+		cp.Resources = append(cp.Resources, checkpointResource{
+			Resource:   r.Resource,
+			ID:         r.ID,
+			Name:       r.Name,
+			Mode:       r.Mode,
+			Attributes: state.Attributes,
+		})
+	}
+	return cp
+}
+
+// saveCheckpoint atomically writes the current progress of the export into the output directory.
+// Nothing is saved after interruption, as failing imports of cancelled export aren't pending anymore
+func (ic *importContext) saveCheckpoint() error {
+	ic.checkpointMutex.Lock()
+	defer ic.checkpointMutex.Unlock()
+	if ic.interrupted {
+		return nil
+	}
+	return ic.writeCheckpoint()
+}
+
+func (ic *importContext) writeCheckpoint() error {
+	cp := ic.buildCheckpoint()
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	fileName := ic.checkpointFile()
+	tmpFileName := fileName + ".tmp"
+	if err = os.WriteFile(tmpFileName, data, 0644); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpFileName, fileName); err != nil {
+		return err
+	}
+	log.Printf("[INFO] Saved checkpoint with %d imported and %d pending resources into %s",
+		len(cp.Resources), len(cp.Pending), fileName)
+	return nil
+}
+
+func (ic *importContext) removeCheckpoint() {
+	err := os.Remove(ic.checkpointFile())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("[WARN] can't remove checkpoint file: %v", err)
+	}
+}
+
+func loadCheckpoint(fileName string) (*checkpoint, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	var cp checkpoint
+	if err = json.Unmarshal(content, &cp); err != nil {
+		return nil, fmt.Errorf("can't parse checkpoint file %s: %w", fileName, err)
+	}
+	return &cp, nil
+}
+
+// restoreImported puts resources from the checkpoint into the state, so they won't be read again.
+// It must be called before the listing is started
+func (ic *importContext) restoreImported(cp *checkpoint) {
+	for _, cr := range cp.Resources {
+		pr, ok := ic.Resources[cr.Resource]
+		if !ok {
+			log.Printf("[WARN] %s from checkpoint is not available in provider", cr.Resource)
+			continue
+		}
+		r := &resource{
+			Resource: cr.Resource,
+			ID:       cr.ID,
+			Name:     cr.Name,
+			Mode:     cr.Mode,
+			Data: pr.Data(&terraform.InstanceState{
+				ID:         cr.ID,
+				Attributes: cr.Attributes,
+			}),
+		}
+		ic.Add(r)
+	}
+	log.Printf("[INFO] Restored %d imported resources from checkpoint", len(cp.Resources))
+}
+
+// emitPending sends resources that weren't imported before interruption into import channels
+func (ic *importContext) emitPending(cp *checkpoint) {
+	for _, p := range cp.Pending {
+		ic.Emit(&resource{
+			Resource:  p.Resource,
+			ID:        p.ID,
+			Attribute: p.Attribute,
+			Value:     p.Value,
+			Name:      p.Name,
+			Mode:      p.Mode,
+		})
+	}
+	log.Printf("[INFO] Emitted %d pending resources from checkpoint", len(cp.Pending))
+}
+
+// startCheckpointing periodically saves checkpoints until the returned function is called
+func (ic *importContext) startCheckpointing() func() {
+	if ic.checkpointInterval <= 0 {
+		return func() {}
+	}
+	ticker := time.NewTicker(ic.checkpointInterval)
+	done := make(chan bool)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := ic.saveCheckpoint(); err != nil {
+					log.Printf("[ERROR] can't save checkpoint: %v", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		ticker.Stop()
+		close(done)
+	}
+}
+
+// handleInterrupts saves the checkpoint on Ctrl-C and cancels the export, so it could be continued
+// with -resume. The second Ctrl-C terminates the process. The returned function stops the handling
+func (ic *importContext) handleInterrupts(cancel context.CancelFunc) func() {
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	done := make(chan bool)
+	go func() {
+		select {
+		case <-done:
+			return
+		case <-interrupts:
+			signal.Stop(interrupts)
+		}
+		log.Printf("[WARN] Export is interrupted, saving checkpoint")
+		ic.checkpointMutex.Lock()
+		defer ic.checkpointMutex.Unlock()
+		if err := ic.writeCheckpoint(); err != nil {
+			log.Printf("[ERROR] can't save checkpoint: %v", err)
+		}
+		ic.interrupted = true
+		cancel()
+	}()
+	return func() {
+		signal.Stop(interrupts)
+		close(done)
+	}
+}
+
+// waitForImport waits until all resources are listed & imported or the export is cancelled
+func (ic *importContext) waitForImport() error {
+	finished := make(chan bool)
+	go func() {
+		ic.waitGroup.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		return nil
+	case <-ic.Context.Done():
+		return fmt.Errorf("export is interrupted, use -resume to continue it from %s", ic.checkpointFile())
+	}
+}
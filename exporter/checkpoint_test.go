@@ -0,0 +1,153 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/databricks/terraform-provider-databricks/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	tmpDir := fmt.Sprintf("/tmp/tf-%s", qa.RandomName())
+	require.NoError(t, os.MkdirAll(tmpDir, 0755))
+	defer os.RemoveAll(tmpDir)
+
+	ic := importContextForTest()
+	ic.Directory = tmpDir
+	ic.services = "notebooks"
+	ic.importing = map[string]bool{}
+	d := workspace.ResourceNotebook().TestResourceData()
+	d.SetId("/Users/user@domain.com/abc")
+	d.Set("path", "/Users/user@domain.com/abc")
+	d.Set("language", "PYTHON")
+	ic.Add(&resource{
+		Resource: "databricks_notebook",
+		ID:       "/Users/user@domain.com/abc",
+		Name:     "abc",
+		Data:     d,
+	})
+	ic.addPending(&resource{
+		Resource: "databricks_directory",
+		ID:       "/Users/user@domain.com",
+	})
+	require.NoError(t, ic.saveCheckpoint())
+
+	cp, err := loadCheckpoint(ic.checkpointFile())
+	require.NoError(t, err)
+	assert.Equal(t, "notebooks", cp.Services)
+	assert.NoError(t, ic.checkResumeFilters(cp))
+	require.Len(t, cp.Resources, 1)
+	assert.Equal(t, "databricks_notebook", cp.Resources[0].Resource)
+	assert.Equal(t, "PYTHON", cp.Resources[0].Attributes["language"])
+	assert.Equal(t, []checkpointPending{{
+		Resource: "databricks_directory",
+		ID:       "/Users/user@domain.com",
+	}}, cp.Pending)
+
+	resumed := importContextForTest()
+	resumed.importing = map[string]bool{}
+	resumed.restoreImported(cp)
+	assert.True(t, resumed.Has(&resource{
+		Resource: "databricks_notebook",
+		ID:       "/Users/user@domain.com/abc",
+	}))
+	restored := resumed.Scope.Sorted()
+	require.Len(t, restored, 1)
+	assert.Equal(t, "/Users/user@domain.com/abc", restored[0].Data.Get("path"))
+
+	resumed.emitPending(cp)
+	assert.Equal(t, map[string]bool{
+		"databricks_directory[<unknown>] (id: /Users/user@domain.com)": true,
+	}, resumed.testEmits)
+
+	ic.removeCheckpoint()
+	_, err = os.Stat(ic.checkpointFile())
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLoadCheckpointErrors(t *testing.T) {
+	tmpDir := fmt.Sprintf("/tmp/tf-%s", qa.RandomName())
+	require.NoError(t, os.MkdirAll(tmpDir, 0755))
+	defer os.RemoveAll(tmpDir)
+
+	fileName := tmpDir + "/" + checkpointFileName
+	_, err := loadCheckpoint(fileName)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, os.WriteFile(fileName, []byte("{"), 0644))
+	_, err = loadCheckpoint(fileName)
+	assert.ErrorContains(t, err, "can't parse checkpoint file "+fileName)
+}
+
+func TestPendingIsRemovedAfterImport(t *testing.T) {
+	ic := importContextForTest()
+	r := &resource{
+		Resource: "databricks_directory",
+		ID:       "/Users/user@domain.com",
+	}
+	ic.addPending(r)
+	assert.Len(t, ic.buildCheckpoint().Pending, 1)
+	ic.removePending(r.String())
+	assert.Len(t, ic.buildCheckpoint().Pending, 0)
+}
+
+func TestCheckResumeFilters(t *testing.T) {
+	ic := importContextForTest()
+	ic.Directory = "/tmp/export"
+	ic.services = "jobs,compute"
+	ic.listing = "jobs"
+	ic.ownersStr = "user@domain.com"
+	cp := ic.buildCheckpoint()
+	assert.NoError(t, ic.checkResumeFilters(&cp))
+
+	ic.ownersStr = ""
+	assert.EqualError(t, ic.checkResumeFilters(&cp), "checkpoint /tmp/export/exporter-checkpoint.json "+
+		`was created with filters {"services":"jobs,compute","listing":"jobs","owners":"user@domain.com"}, `+
+		`but resuming with {"services":"jobs,compute","listing":"jobs"}. `+
+		"Use the same filters or remove the checkpoint file")
+}
+
+func TestInterruptSavesCheckpointAndCancelsExport(t *testing.T) {
+	tmpDir := fmt.Sprintf("/tmp/tf-%s", qa.RandomName())
+	require.NoError(t, os.MkdirAll(tmpDir, 0755))
+	defer os.RemoveAll(tmpDir)
+
+	ic := importContextForTest()
+	ic.Directory = tmpDir
+	ic.services = "notebooks"
+	pending := &resource{
+		Resource: "databricks_directory",
+		ID:       "/Users/user@domain.com",
+	}
+	ic.addPending(pending)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ic.Context = ctx
+	stop := ic.handleInterrupts(cancel)
+	defer stop()
+	// listing that never finishes
+	ic.waitGroup.Add(1)
+	defer ic.waitGroup.Done()
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGINT))
+	err := ic.waitForImport()
+	assert.EqualError(t, err, "export is interrupted, use -resume to continue it from "+ic.checkpointFile())
+
+	cp, err := loadCheckpoint(ic.checkpointFile())
+	require.NoError(t, err)
+	assert.Len(t, cp.Pending, 1)
+
+	// imports failing because of cancellation must not overwrite the checkpoint
+	ic.removePending(pending.String())
+	assert.Len(t, ic.buildCheckpoint().Pending, 0)
+	require.NoError(t, ic.saveCheckpoint())
+	cp, err = loadCheckpoint(ic.checkpointFile())
+	require.NoError(t, err)
+	assert.Len(t, cp.Pending, 1)
+}
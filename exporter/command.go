@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/databricks/databricks-sdk-go/client"
 	"github.com/databricks/databricks-sdk-go/config"
@@ -89,7 +90,7 @@ func Run(args ...string) error {
 	flags.StringVar(&ic.Module, "module", "",
 		"Terraform module name, that changes are imported. "+
 			"Defaults to empty string. Makes effect on generated "+
-			"import.sh file")
+			"import.sh file. Can't be used with -outputFormat=manifest")
 
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -114,6 +115,20 @@ func Run(args ...string) error {
 		"Generate Databricks provider declaration.")
 	flags.StringVar(&ic.notebooksFormat, "notebooksFormat", "SOURCE",
 		"Format to export notebooks: SOURCE, DBC, JUPYTER. Default: SOURCE")
+	flags.StringVar(&ic.outputFormat, "outputFormat", outputFormatHcl,
+		"Format of the generated output: `hcl` generates `import.sh` script, `manifest` generates "+
+			"`import.tf` with import blocks (Terraform 1.5+) together with `manifest.json`. Default: hcl")
+	flags.StringVar(&ic.parameterizeFile, "parameterize", "",
+		"Path to JSON file with rules (resource type and attribute path) describing which attributes "+
+			"should be replaced with variables. Current values are written into `terraform.tfvars`")
+	flags.BoolVar(&ic.exportGraph, "graph", false,
+		"Write graph of exported resources and references between them into `graph.dot` and `graph.json`")
+	flags.BoolVar(&ic.graphOnly, "graphOnly", false,
+		"Write only the graph of exported resources without the generated code, i.e. to review the export. "+
+			"Implies -graph")
+	flags.StringVar(&ic.driftStateFile, "driftReport", "",
+		"Path to the existing `terraform.tfstate` file. If specified, `drift-report.json` is written with objects "+
+			"that aren't managed by Terraform, and with state entries pointing to deleted objects")
 	services, listing := ic.allServicesAndListing()
 	flags.StringVar(&ic.services, "services", services,
 		"Comma-separated list of services to import. By default all services are imported.")
@@ -124,6 +139,27 @@ func Run(args ...string) error {
 	flags.StringVar(&ic.match, "match", "", "Match resource names during listing operation. "+
 		"This filter applies to all resources that are getting listed, so if you want to import "+
 		"all dependencies of just one cluster, specify -listing=compute")
+	flags.StringVar(&ic.includeRegexStr, "includeRegex", "",
+		"Include only resources with names (or paths for notebooks & files) matching the given regular expression "+
+			"during listing operation")
+	flags.StringVar(&ic.excludeRegexStr, "excludeRegex", "",
+		"Exclude resources with names (or paths for notebooks & files) matching the given regular expression "+
+			"during listing operation")
+	flags.StringVar(&ic.ownersStr, "owners", "",
+		"Comma-separated list of owners (creators) of listed resources, i.e. clusters, jobs, pipelines, "+
+			"SQL queries, dashboards & alerts. Resources of other types aren't filtered, and resources without "+
+			"owner information are skipped, unless -includeUnknownOwners is specified")
+	flags.BoolVar(&ic.unknownOwners, "includeUnknownOwners", false,
+		"Include listed resources without owner information, i.e. SQL alerts of deleted users, when -owners is specified")
+	flags.StringVar(&ic.tagsStr, "tags", "",
+		"Comma-separated list of tags in form of key=value or key, that listed resources must have, i.e. "+
+			"custom tags of clusters, instance pools & SQL warehouses, tags of jobs, SQL queries & dashboards. "+
+			"Resources without tags support aren't filtered")
+	flags.BoolVar(&ic.resume, "resume", false,
+		"Resume interrupted export from the checkpoint saved in the output directory")
+	flags.DurationVar(&ic.checkpointInterval, "checkpointInterval", time.Minute,
+		"How often to save progress of the export into `exporter-checkpoint.json` in the output directory. "+
+			"Set to 0 to disable checkpoints")
 	prefix := ""
 	flags.StringVar(&prefix, "prefix", "", "Prefix that will be added to the name of all exported resources")
 	newArgs := args
@@ -140,6 +176,9 @@ func Run(args ...string) error {
 	if len(prefix) > 0 {
 		ic.prefix = prefix + "_"
 	}
+	if ic.graphOnly {
+		ic.exportGraph = true
+	}
 	if ic.debug {
 		logLevel = append(logLevel, "[DEBUG]")
 	}
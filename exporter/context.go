@@ -65,6 +65,7 @@ type importContext struct {
 	nameFixes         []regexFix
 	hclFixes          []regexFix
 	variables         map[string]string
+	variableValues    map[string]cty.Value
 	workspaceConfKeys map[string]any
 
 	channels map[string]resourceChannel
@@ -93,6 +94,23 @@ type importContext struct {
 	notebooksFormat     string
 	updatedSinceStr     string
 	updatedSinceMs      int64
+	outputFormat        string
+	parameterizeFile    string
+	parameterizeRules   []parameterizeRule
+	exportGraph         bool
+	graphOnly           bool
+	driftStateFile      string
+	includeRegexStr     string
+	includeRegex        *regexp.Regexp
+	excludeRegexStr     string
+	excludeRegex        *regexp.Regexp
+	ownersStr           string
+	owners              map[string]bool
+	unknownOwners       bool
+	tagsStr             string
+	tags                map[string]string
+	resume              bool
+	checkpointInterval  time.Duration
 
 	waitGroup *sync.WaitGroup
 
@@ -123,6 +141,18 @@ type importContext struct {
 	sqlDatasources      map[string]string
 	sqlDatasourcesMutex sync.Mutex
 
+	// emitted resources that weren't imported yet, used for checkpoints
+	pending      map[string]*resource
+	pendingMutex sync.Mutex
+
+	// serializes saving of checkpoints, that aren't saved anymore after interruption
+	checkpointMutex sync.Mutex
+	interrupted     bool
+
+	// entries of the generated manifest & resource graph, filled in the single thread
+	manifest []manifestEntry
+	graph    resourceGraph
+
 	// workspace-related objects & corresponding mutex
 	allDirectories      []workspace.ObjectStatus
 	allWorkspaceObjects []workspace.ObjectStatus
@@ -207,15 +237,18 @@ func newImportContext(c *common.DatabricksClient) *importContext {
 		Files:       map[string]*hclwrite.File{},
 		Scope:       importedResources{},
 		importing:   map[string]bool{},
+		pending:     map[string]*resource{},
 		nameFixes:   nameFixes,
 		hclFixes:    []regexFix{ // Be careful with that! it may break working code
 		},
 		variables:           map[string]string{},
+		variableValues:      map[string]cty.Value{},
 		allDirectories:      []workspace.ObjectStatus{},
 		allWorkspaceObjects: []workspace.ObjectStatus{},
 		workspaceConfKeys:   workspaceConfKeys,
 		shImports:           make(map[string]bool),
 		notebooksFormat:     "SOURCE",
+		outputFormat:        outputFormatHcl,
 		allUsers:            map[string]scim.User{},
 		allSps:              map[string]scim.User{},
 		waitGroup:           &sync.WaitGroup{},
@@ -275,6 +308,32 @@ func (ic *importContext) Run() error {
 	if !supportedFormat && ic.notebooksFormat != "SOURCE" {
 		return fmt.Errorf("unsupported notebook format: '%s'", ic.notebooksFormat)
 	}
+	if ic.outputFormat != outputFormatHcl && ic.outputFormat != outputFormatManifest {
+		return fmt.Errorf("unsupported output format: '%s'", ic.outputFormat)
+	}
+	if ic.outputFormat == outputFormatManifest && ic.Module != "" {
+		// Terraform doesn't allow `import` blocks outside of the root module
+		return fmt.Errorf("-module can't be used with -outputFormat=%s, because import blocks "+
+			"are only allowed in the root module", outputFormatManifest)
+	}
+	if err := ic.initFilters(); err != nil {
+		return err
+	}
+	if ic.parameterizeFile != "" {
+		rules, err := loadParameterizeRules(ic.parameterizeFile)
+		if err != nil {
+			return err
+		}
+		ic.parameterizeRules = rules
+	}
+	var managed driftState
+	if ic.driftStateFile != "" {
+		state, err := loadTfState(ic.driftStateFile)
+		if err != nil {
+			return err
+		}
+		managed = state
+	}
 
 	info, err := os.Stat(ic.Directory)
 	if os.IsNotExist(err) {
@@ -305,12 +364,38 @@ func (ic *importContext) Run() error {
 			}
 		}
 	}
+	var resumeFrom *checkpoint
+	if ic.resume {
+		cp, err := loadCheckpoint(ic.checkpointFile())
+		if errors.Is(err, os.ErrNotExist) {
+			log.Printf("[WARN] Checkpoint file %s doesn't exist, starting from scratch", ic.checkpointFile())
+		} else if err != nil {
+			return err
+		} else if err = ic.checkResumeFilters(cp); err != nil {
+			return err
+		} else {
+			resumeFrom = cp
+			ic.restoreImported(cp)
+		}
+	}
+
 	// Concurrent execution part
 	if ic.waitGroup == nil {
 		ic.waitGroup = &sync.WaitGroup{}
 	}
 	// Start goroutines for each resource type
+	if ic.checkpointInterval > 0 {
+		ctx, cancel := context.WithCancel(ic.Context)
+		defer cancel()
+		ic.Context = ctx
+		stopHandlingInterrupts := ic.handleInterrupts(cancel)
+		defer stopHandlingInterrupts()
+	}
 	ic.startImportChannels()
+	stopCheckpointing := ic.startCheckpointing()
+	if resumeFrom != nil {
+		ic.emitPending(resumeFrom)
+	}
 
 	// Start listing of objects
 	for rnLoop, irLoop := range ic.Importables {
@@ -341,40 +426,60 @@ func (ic *importContext) Run() error {
 		}()
 	}
 
-	ic.waitGroup.Wait()
+	if err = ic.waitForImport(); err != nil {
+		stopCheckpointing()
+		return err
+	}
 	// close channels
 	ic.closeImportChannels()
+	stopCheckpointing()
+	if ic.checkpointInterval > 0 {
+		// all resources are imported, so the following steps could be retried without reading them again
+		if err = ic.saveCheckpoint(); err != nil {
+			log.Printf("[ERROR] can't save checkpoint: %v", err)
+		}
+	}
 
 	// This should be single threaded...
 	if ic.Scope.Len() == 0 {
 		return fmt.Errorf("no resources to import")
 	}
-	shFileName := fmt.Sprintf("%s/import.sh", ic.Directory)
-	if ic.incremental {
-		shFile, err := os.Open(shFileName)
-		if err == nil {
-			defer shFile.Close()
-			fileScanner := bufio.NewScanner(shFile)
-			fileScanner.Split(bufio.ScanLines)
-			for fileScanner.Scan() {
-				line := fileScanner.Text()
-				if strings.HasPrefix(line, "terraform import ") {
-					ic.shImports[strings.TrimRight(line, "\n")] = true
+	if managed != nil {
+		err = ic.generateDriftReport(managed)
+		if err != nil {
+			return err
+		}
+	}
+	// import.tf is generated instead of import.sh for manifest output format
+	var sh *os.File
+	if ic.outputFormat == outputFormatHcl && !ic.graphOnly {
+		shFileName := fmt.Sprintf("%s/import.sh", ic.Directory)
+		if ic.incremental {
+			shFile, err := os.Open(shFileName)
+			if err == nil {
+				defer shFile.Close()
+				fileScanner := bufio.NewScanner(shFile)
+				fileScanner.Split(bufio.ScanLines)
+				for fileScanner.Scan() {
+					line := fileScanner.Text()
+					if strings.HasPrefix(line, "terraform import ") {
+						ic.shImports[strings.TrimRight(line, "\n")] = true
+					}
 				}
+			} else {
+				log.Printf("[ERROR] opening %s: %v", shFileName, err)
 			}
-		} else {
-			log.Printf("[ERROR] opening %s: %v", shFileName, err)
 		}
+		sh, err = os.OpenFile(shFileName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+		if err != nil {
+			return err
+		}
+		defer sh.Close()
+		// nolint
+		sh.WriteString("#!/bin/sh\n\nset -e\n\n")
 	}
-	sh, err := os.OpenFile(shFileName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
-	if err != nil {
-		return err
-	}
-	defer sh.Close()
-	// nolint
-	sh.WriteString("#!/bin/sh\n\nset -e\n\n")
 
-	if ic.generateDeclaration {
+	if ic.generateDeclaration && !ic.graphOnly {
 		dcfile, err := os.Create(fmt.Sprintf("%s/databricks.tf", ic.Directory))
 		if err != nil {
 			return err
@@ -401,6 +506,18 @@ func (ic *importContext) Run() error {
 		dcfile.Close()
 	}
 	ic.generateHclForResources(sh)
+	if ic.exportGraph {
+		// references are resolved in the generated code, but the graph is written before it
+		err = ic.generateGraph()
+		if err != nil {
+			return err
+		}
+	}
+	if ic.graphOnly {
+		// imported resources stay in the checkpoint, so the code could be generated with -resume
+		log.Printf("[INFO] Done. Only the graph is written with -graphOnly")
+		return nil
+	}
 	for service, f := range ic.Files {
 		generatedFile := fmt.Sprintf("%s/%s.tf", ic.Directory, service)
 		err = ic.updateExportedWithIncrementals(generatedFile, f)
@@ -425,6 +542,16 @@ func (ic *importContext) Run() error {
 	if err != nil {
 		return err
 	}
+	err = ic.generateTfvars()
+	if err != nil {
+		return err
+	}
+	if ic.outputFormat == outputFormatManifest {
+		err = ic.generateManifest()
+		if err != nil {
+			return err
+		}
+	}
 
 	//
 	if stats, err := os.Create(statsFileName); err == nil {
@@ -450,6 +577,7 @@ func (ic *importContext) Run() error {
 			return err
 		}
 	}
+	ic.removeCheckpoint()
 	log.Printf("[INFO] Done. Please edit the files and roll out new environment.")
 	return nil
 }
@@ -595,11 +723,21 @@ func (ic *importContext) generateHclForResources(sh *os.File) {
 			continue
 		}
 		body := f.Body()
+		// generated blocks are required only for post-processing, as it's expensive to find them for custom bodies
+		trackBlocks := ic.outputFormat == outputFormatManifest || len(ic.parameterizeRules) > 0 || ic.exportGraph
+		var generatedBlocks []*hclwrite.Block
 		if ir.Body != nil {
+			blocksBefore := 0
+			if trackBlocks {
+				blocksBefore = len(body.Blocks())
+			}
 			err := ir.Body(ic, body, r)
 			if err != nil {
 				log.Printf("[ERROR] %s", err.Error())
 			}
+			if trackBlocks {
+				generatedBlocks = body.Blocks()[blocksBefore:]
+			}
 		} else {
 			resourceBlock := body.AppendNewBlock("resource", []string{r.Resource, r.Name})
 			err := ic.dataToHcl(ir, []string{}, ic.Resources[r.Resource],
@@ -607,6 +745,16 @@ func (ic *importContext) generateHclForResources(sh *os.File) {
 			if err != nil {
 				log.Printf("[ERROR] %s", err.Error())
 			}
+			generatedBlocks = []*hclwrite.Block{resourceBlock}
+		}
+		if len(ic.parameterizeRules) > 0 {
+			ic.parameterize(generatedBlocks)
+		}
+		if ic.outputFormat == outputFormatManifest {
+			ic.addManifestEntry(r, ir.Service, generatedBlocks)
+		}
+		if ic.exportGraph {
+			ic.addGraphNode(r, ir, generatedBlocks)
 		}
 		if i%50 == 0 {
 			log.Printf("[INFO] Generated %d of %d resources", i+1, scopeSize)
@@ -618,6 +766,9 @@ func (ic *importContext) generateHclForResources(sh *os.File) {
 			delete(ic.shImports, importCommand)
 		}
 	}
+	if sh == nil {
+		return
+	}
 	log.Printf("[DEBUG] Writing the rest of import commands. len=%d", len(ic.shImports))
 	for k := range ic.shImports {
 		sh.WriteString(k + "\n")
@@ -625,6 +776,12 @@ func (ic *importContext) generateHclForResources(sh *os.File) {
 }
 
 func (ic *importContext) MatchesName(n string) bool {
+	if ic.includeRegex != nil && !ic.includeRegex.MatchString(n) {
+		return false
+	}
+	if ic.excludeRegex != nil && ic.excludeRegex.MatchString(n) {
+		return false
+	}
 	if ic.match == "" {
 		return true
 	}
@@ -632,7 +789,7 @@ func (ic *importContext) MatchesName(n string) bool {
 }
 
 // this will run single threaded
-func (ic *importContext) Find(r *resource, pick string, ref reference) (string, hcl.Traversal) {
+func (ic *importContext) Find(r *resource, pick string, ref reference, d *schema.ResourceData) (string, hcl.Traversal) {
 	for _, sr := range ic.State.Resources() {
 		if sr.Type != r.Resource {
 			continue
@@ -651,6 +808,9 @@ func (ic *importContext) Find(r *resource, pick string, ref reference) (string,
 			}
 			matchValue = res[1]
 		}
+		if ref.IsValidApproximation != nil && !ref.IsValidApproximation(ic, d, sr) {
+			continue
+		}
 		for _, i := range sr.Instances {
 			v := i.Attributes[r.Attribute]
 			if v == nil {
@@ -825,6 +985,7 @@ func (ic *importContext) Emit(r *resource) {
 	if exists {
 		log.Printf("[TRACE] increasing counter & sending to the channel for resource %s", r.Resource)
 		ic.waitGroup.Add(1)
+		ic.addPending(r)
 		ch <- r
 	} else {
 		log.Printf("[WARN] Can't find channel for resource %s", r.Resource)
@@ -837,14 +998,14 @@ func maybeAddQuoteCharacter(s string) string {
 	return s
 }
 
-func (ic *importContext) getTraversalTokens(ref reference, value string) hclwrite.Tokens {
+func (ic *importContext) getTraversalTokens(ref reference, value string, d *schema.ResourceData) hclwrite.Tokens {
 	matchType := ref.MatchTypeValue()
 	attr := ref.MatchAttribute()
 	attrValue, traversal := ic.Find(&resource{
 		Resource:  ref.Resource,
 		Attribute: attr,
 		Value:     value,
-	}, attr, ref)
+	}, attr, ref, d)
 	// at least one invocation of ic.Find will assign Nil to traversal if resource with value is not found
 	if traversal == nil {
 		return nil
@@ -882,7 +1043,7 @@ func (ic *importContext) getTraversalTokens(ref reference, value string) hclwrit
 // TODO: move to IC
 var dependsRe = regexp.MustCompile(`(\.[\d]+)`)
 
-func (ic *importContext) reference(i importable, path []string, value string) hclwrite.Tokens {
+func (ic *importContext) reference(i importable, path []string, value string, data *schema.ResourceData) hclwrite.Tokens {
 	match := dependsRe.ReplaceAllString(strings.Join(path, "."), "")
 	for _, d := range i.Depends {
 		if d.Path != match {
@@ -900,7 +1061,7 @@ func (ic *importContext) reference(i importable, path []string, value string) hc
 			return ic.variable(fmt.Sprintf("%s_%s", path[0], value), "")
 		}
 
-		if tokens := ic.getTraversalTokens(d, value); tokens != nil {
+		if tokens := ic.getTraversalTokens(d, value, data); tokens != nil {
 			return tokens
 		}
 	}
@@ -957,7 +1118,7 @@ func (ic *importContext) dataToHcl(i importable, path []string,
 		}
 		switch as.Type {
 		case schema.TypeString:
-			body.SetAttributeRaw(a, ic.reference(i, append(path, a), raw.(string)))
+			body.SetAttributeRaw(a, ic.reference(i, append(path, a), raw.(string), d))
 		case schema.TypeBool:
 			body.SetAttributeValue(a, cty.BoolVal(raw.(bool)))
 		case schema.TypeInt:
@@ -1039,7 +1200,7 @@ func (ic *importContext) readListFromData(i importable, path []string, d *schema
 			}
 			switch x := raw.(type) {
 			case string:
-				toks = append(toks, ic.reference(i, path, x)...)
+				toks = append(toks, ic.reference(i, path, x, d)...)
 			case int:
 				// probably we don't even use integer lists?...
 				toks = append(toks, hclwrite.TokensForValue(
@@ -39,7 +39,7 @@ func TestImportContextFindSkips(t *testing.T) {
 		Resource:  "a",
 		Attribute: "b",
 		Name:      "c",
-	}, "x", reference{})
+	}, "x", reference{}, nil)
 	assert.Nil(t, traversal)
 }
 
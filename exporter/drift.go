@@ -0,0 +1,220 @@
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// subset of Terraform state file (version 4) that is required for drift detection
+type tfState struct {
+	Resources []struct {
+		Module    string `json:"module,omitempty"`
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			IndexKey   any            `json:"index_key,omitempty"`
+			Attributes map[string]any `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+// driftStateKey identifies an object managed by Terraform, because IDs are unique only within a resource type
+type driftStateKey struct {
+	Type string
+	ID   string
+}
+
+// driftState maps managed objects to their addresses. The same object could be managed more than once,
+// e.g. from different modules.
+type driftState map[driftStateKey][]string
+
+// sideEffectReads are resource types, that can't be read without creating or starting compute,
+// because they are read through commands on a cluster. Drift report must be read-only, so
+// existence of these objects isn't checked.
+var sideEffectReads = map[string]bool{
+	"databricks_mount":                 true,
+	"databricks_aws_s3_mount":          true,
+	"databricks_azure_adls_gen1_mount": true,
+	"databricks_azure_adls_gen2_mount": true,
+	"databricks_azure_blob_mount":      true,
+	"databricks_sql_permissions":       true,
+}
+
+type driftEntry struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	// Address of the resource in the Terraform state, or of the generated resource for unmanaged objects
+	Address string `json:"address"`
+}
+
+type driftReport struct {
+	// objects that exist in Databricks, but aren't managed by Terraform state
+	Unmanaged []driftEntry `json:"unmanaged"`
+	// entries of the Terraform state that point to deleted objects
+	Deleted []driftEntry `json:"deleted"`
+	// entries of the Terraform state, which existence isn't checked, because reading them has side effects
+	Unchecked []driftEntry `json:"unchecked"`
+}
+
+// loadTfState returns addresses of managed Databricks resources by resource type & ID
+func loadTfState(fileName string) (driftState, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	var state tfState
+	if err = json.Unmarshal(content, &state); err != nil {
+		return nil, fmt.Errorf("can't parse state file %s: %w", fileName, err)
+	}
+	result := driftState{}
+	for _, r := range state.Resources {
+		if r.Mode != "managed" || !strings.HasPrefix(r.Type, "databricks_") {
+			continue
+		}
+		address := r.Type + "." + r.Name
+		if r.Module != "" {
+			address = r.Module + "." + address
+		}
+		for _, instance := range r.Instances {
+			id, ok := instance.Attributes["id"].(string)
+			if !ok || id == "" {
+				continue
+			}
+			instanceAddress := address
+			switch key := instance.IndexKey.(type) {
+			case string:
+				instanceAddress = fmt.Sprintf("%s[%q]", address, key)
+			case float64:
+				instanceAddress = fmt.Sprintf("%s[%d]", address, int64(key))
+			}
+			key := driftStateKey{Type: r.Type, ID: id}
+			result[key] = append(result[key], instanceAddress)
+		}
+	}
+	return result, nil
+}
+
+// isInScope checks if the given resource type is handled by the current export
+func (ic *importContext) isInScope(resourceType string) bool {
+	ir, ok := ic.Importables[resourceType]
+	if !ok || !strings.Contains(ic.services, ir.Service) {
+		return false
+	}
+	if ic.accountLevel {
+		return ir.AccountLevel
+	}
+	return ir.WorkspaceLevel
+}
+
+// objectExists reads the object from Databricks, returning false if the object was deleted
+func (ic *importContext) objectExists(resourceType, id string) (bool, error) {
+	pr, ok := ic.Resources[resourceType]
+	if !ok {
+		return false, fmt.Errorf("%s is not available in provider", resourceType)
+	}
+	d := pr.Data(&terraform.InstanceState{
+		Attributes: map[string]string{},
+		ID:         id,
+	})
+	ctx := context.WithValue(ic.Context, common.ResourceName, strings.ReplaceAll(resourceType, "databricks_", ""))
+	if apiVersion := ic.Importables[resourceType].ApiVersion; apiVersion != "" {
+		ctx = context.WithValue(ctx, common.Api, apiVersion)
+	}
+	if dia := pr.ReadContext(ctx, d, ic.Client); dia != nil {
+		return false, fmt.Errorf("%v", dia)
+	}
+	// resource's Read sets empty ID when object isn't found
+	return d.Id() != "", nil
+}
+
+func (ic *importContext) buildDriftReport(state driftState) driftReport {
+	report := driftReport{
+		Unmanaged: []driftEntry{},
+		Deleted:   []driftEntry{},
+		Unchecked: []driftEntry{},
+	}
+	exported := map[driftStateKey]bool{}
+	for _, r := range ic.Scope.Sorted() {
+		if r.Mode == "data" || ic.Resources[r.Resource].Importer == nil {
+			continue
+		}
+		key := driftStateKey{Type: r.Resource, ID: r.ID}
+		exported[key] = true
+		if _, managed := state[key]; !managed {
+			report.Unmanaged = append(report.Unmanaged, driftEntry{
+				Type:    r.Resource,
+				ID:      r.ID,
+				Address: r.Resource + "." + r.Name,
+			})
+		}
+	}
+	for key, addresses := range state {
+		if !ic.isInScope(key.Type) {
+			log.Printf("[DEBUG] %s isn't part of the export, skipping drift detection", key.Type)
+			continue
+		}
+		if exported[key] {
+			continue
+		}
+		entries := []driftEntry{}
+		for _, address := range addresses {
+			entries = append(entries, driftEntry{
+				Type:    key.Type,
+				ID:      key.ID,
+				Address: address,
+			})
+		}
+		if sideEffectReads[key.Type] {
+			report.Unchecked = append(report.Unchecked, entries...)
+			continue
+		}
+		exists, err := ic.objectExists(key.Type, key.ID)
+		if err != nil {
+			log.Printf("[WARN] can't check existence of %s (%s): %v", key.Type, key.ID, err)
+			continue
+		}
+		if !exists {
+			report.Deleted = append(report.Deleted, entries...)
+		}
+	}
+	sort.Slice(report.Deleted, func(i, j int) bool {
+		return report.Deleted[i].Address < report.Deleted[j].Address
+	})
+	sort.Slice(report.Unchecked, func(i, j int) bool {
+		return report.Unchecked[i].Address < report.Unchecked[j].Address
+	})
+	return report
+}
+
+func (ic *importContext) generateDriftReport(state driftState) error {
+	report := ic.buildDriftReport(state)
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	fileName := fmt.Sprintf("%s/drift-report.json", ic.Directory)
+	if err = os.WriteFile(fileName, data, 0644); err != nil {
+		return err
+	}
+	for _, e := range report.Unmanaged {
+		log.Printf("[INFO] Unmanaged: %s (id: %s) isn't in the Terraform state", e.Address, e.ID)
+	}
+	for _, e := range report.Deleted {
+		log.Printf("[INFO] Deleted: %s (id: %s) points to a deleted object", e.Address, e.ID)
+	}
+	for _, e := range report.Unchecked {
+		log.Printf("[INFO] Unchecked: %s (id: %s) can't be read without starting a cluster", e.Address, e.ID)
+	}
+	log.Printf("[INFO] Written drift report with %d unmanaged, %d deleted and %d unchecked objects into %s",
+		len(report.Unmanaged), len(report.Deleted), len(report.Unchecked), fileName)
+	return nil
+}
@@ -0,0 +1,194 @@
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTfState = `{
+  "version": 4,
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "databricks_instance_pool",
+      "name": "existing",
+      "instances": [{"attributes": {"id": "pool1"}}]
+    },
+    {
+      "module": "module.workspace",
+      "mode": "managed",
+      "type": "databricks_instance_pool",
+      "name": "pools",
+      "instances": [
+        {"index_key": 0, "attributes": {"id": "pool2"}},
+        {"index_key": "other", "attributes": {"id": "pool4"}}
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "databricks_cluster",
+      "name": "cluster",
+      "instances": [{"attributes": {"id": "c1"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "databricks_cluster_policy",
+      "name": "same_id",
+      "instances": [{"attributes": {"id": "c1"}}]
+    },
+    {
+      "module": "module.other",
+      "mode": "managed",
+      "type": "databricks_instance_pool",
+      "name": "again",
+      "instances": [{"attributes": {"id": "pool2"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "databricks_mount",
+      "name": "data",
+      "instances": [{"attributes": {"id": "data"}}]
+    },
+    {
+      "mode": "data",
+      "type": "databricks_instance_pool",
+      "name": "lookup",
+      "instances": [{"attributes": {"id": "pool5"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "aws_s3_bucket",
+      "name": "bucket",
+      "instances": [{"attributes": {"id": "bucket"}}]
+    }
+  ]
+}`
+
+func TestLoadTfState(t *testing.T) {
+	state, err := loadTfState(writeTempFile(t, testTfState))
+	require.NoError(t, err)
+	assert.Equal(t, driftState{
+		{Type: "databricks_instance_pool", ID: "pool1"}: {"databricks_instance_pool.existing"},
+		{Type: "databricks_instance_pool", ID: "pool2"}: {
+			"module.workspace.databricks_instance_pool.pools[0]",
+			"module.other.databricks_instance_pool.again",
+		},
+		{Type: "databricks_instance_pool", ID: "pool4"}: {
+			`module.workspace.databricks_instance_pool.pools["other"]`,
+		},
+		{Type: "databricks_cluster", ID: "c1"}:        {"databricks_cluster.cluster"},
+		{Type: "databricks_cluster_policy", ID: "c1"}: {"databricks_cluster_policy.same_id"},
+		{Type: "databricks_mount", ID: "data"}:        {"databricks_mount.data"},
+	}, state)
+
+	_, err = loadTfState(writeTempFile(t, `{`))
+	assert.ErrorContains(t, err, "can't parse state file")
+}
+
+func TestDriftReport(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/instance-pools/get?instance_pool_id=pool2",
+			Status:   404,
+			Response: apierr.NotFound("nope"),
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/instance-pools/get?instance_pool_id=pool4",
+			Response: compute.GetInstancePool{
+				InstancePoolId:   "pool4",
+				InstancePoolName: "other",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		ic := importContextForTest()
+		ic.Client = client
+		ic.Context = ctx
+		ic.services = "pools,mounts"
+		ic.Directory = fmt.Sprintf("/tmp/tf-%s", qa.RandomName())
+		require.NoError(t, os.MkdirAll(ic.Directory, 0755))
+		defer os.RemoveAll(ic.Directory)
+		for _, id := range []string{"pool1", "pool3"} {
+			ic.Scope.Append(&resource{
+				Resource: "databricks_instance_pool",
+				ID:       id,
+				Name:     id,
+			})
+		}
+		state, err := loadTfState(writeTempFile(t, testTfState))
+		require.NoError(t, err)
+
+		err = ic.generateDriftReport(state)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(ic.Directory + "/drift-report.json")
+		require.NoError(t, err)
+		var report driftReport
+		require.NoError(t, json.Unmarshal(content, &report))
+		assert.Equal(t, driftReport{
+			Unmanaged: []driftEntry{
+				{
+					Type:    "databricks_instance_pool",
+					ID:      "pool3",
+					Address: "databricks_instance_pool.pool3",
+				},
+			},
+			Deleted: []driftEntry{
+				{
+					Type:    "databricks_instance_pool",
+					ID:      "pool2",
+					Address: "module.other.databricks_instance_pool.again",
+				},
+				{
+					Type:    "databricks_instance_pool",
+					ID:      "pool2",
+					Address: "module.workspace.databricks_instance_pool.pools[0]",
+				},
+			},
+			// reading mounts starts a cluster, so they aren't checked
+			Unchecked: []driftEntry{
+				{
+					Type:    "databricks_mount",
+					ID:      "data",
+					Address: "databricks_mount.data",
+				},
+			},
+		}, report)
+	})
+}
+
+func TestDriftReportReadError(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/instance-pools/get?instance_pool_id=pool1",
+			Status:   400,
+			Response: apierr.APIErrorBody{
+				ErrorCode: "INVALID_REQUEST",
+				Message:   "Internal error happened",
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		ic := importContextForTest()
+		ic.Client = client
+		ic.Context = ctx
+		ic.services = "pools"
+		report := ic.buildDriftReport(driftState{
+			{Type: "databricks_instance_pool", ID: "pool1"}: {"databricks_instance_pool.pool"},
+		})
+		// objects with unknown status aren't reported
+		assert.Len(t, report.Deleted, 0)
+		assert.Len(t, report.Unmanaged, 0)
+	})
+}
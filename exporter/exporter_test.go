@@ -11,10 +11,12 @@ import (
 	"testing"
 	"time"
 
+	sdk_catalog "github.com/databricks/databricks-sdk-go/service/catalog"
 	"github.com/databricks/databricks-sdk-go/service/compute"
 	"github.com/databricks/databricks-sdk-go/service/ml"
 	"github.com/databricks/databricks-sdk-go/service/serving"
 	"github.com/databricks/databricks-sdk-go/service/settings"
+	"github.com/databricks/databricks-sdk-go/service/sharing"
 	workspaceApi "github.com/databricks/databricks-sdk-go/service/workspace"
 	"github.com/databricks/terraform-provider-databricks/aws"
 	"github.com/databricks/terraform-provider-databricks/clusters"
@@ -32,6 +34,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // nolint
@@ -247,6 +250,20 @@ var emptyMlflowWebhooks = qa.HTTPFixture{
 	Response:     ml.ListRegistryWebhooks{},
 }
 
+var emptyMlflowExperiments = qa.HTTPFixture{
+	Method:       "GET",
+	ReuseRequest: true,
+	Resource:     "/api/2.0/mlflow/experiments/list?",
+	Response:     ml.ListExperimentsResponse{},
+}
+
+var emptyMlflowModels = qa.HTTPFixture{
+	Method:       "GET",
+	ReuseRequest: true,
+	Resource:     "/api/2.0/mlflow/registered-models/list?",
+	Response:     ml.ListModelsResponse{},
+}
+
 var emptyRepos = qa.HTTPFixture{
 	Method:       "GET",
 	ReuseRequest: true,
@@ -318,6 +335,48 @@ var emptySqlAlerts = qa.HTTPFixture{
 	ReuseRequest: true,
 }
 
+var emptyUcCatalogs = qa.HTTPFixture{
+	Method:       "GET",
+	Resource:     "/api/2.1/unity-catalog/catalogs",
+	Response:     sdk_catalog.ListCatalogsResponse{},
+	ReuseRequest: true,
+}
+
+var emptyShares = qa.HTTPFixture{
+	Method:       "GET",
+	Resource:     "/api/2.1/unity-catalog/shares",
+	Response:     sharing.ListSharesResponse{},
+	ReuseRequest: true,
+}
+
+var emptyRecipients = qa.HTTPFixture{
+	Method:       "GET",
+	Resource:     "/api/2.1/unity-catalog/recipients?",
+	Response:     sharing.ListRecipientsResponse{},
+	ReuseRequest: true,
+}
+
+var emptyProviders = qa.HTTPFixture{
+	Method:       "GET",
+	Resource:     "/api/2.1/unity-catalog/providers?",
+	Response:     sharing.ListProvidersResponse{},
+	ReuseRequest: true,
+}
+
+var emptyUcStorageCredentials = qa.HTTPFixture{
+	Method:       "GET",
+	Resource:     "/api/2.1/unity-catalog/storage-credentials",
+	Response:     sdk_catalog.ListStorageCredentialsResponse{},
+	ReuseRequest: true,
+}
+
+var emptyUcExternalLocations = qa.HTTPFixture{
+	Method:       "GET",
+	Resource:     "/api/2.1/unity-catalog/external-locations",
+	Response:     sdk_catalog.ListExternalLocationsResponse{},
+	ReuseRequest: true,
+}
+
 var emptyWorkspaceConf = qa.HTTPFixture{
 	Method:       "GET",
 	Resource:     "/api/2.0/workspace-conf?",
@@ -354,8 +413,16 @@ func TestImportingUsersGroupsSecretScopes(t *testing.T) {
 			emptyWorkspace,
 			emptyIpAccessLIst,
 			emptyInstancePools,
+			emptyUcCatalogs,
+			emptyShares,
+			emptyRecipients,
+			emptyProviders,
+			emptyUcStorageCredentials,
+			emptyUcExternalLocations,
 			emptyModelServing,
 			emptyMlflowWebhooks,
+			emptyMlflowExperiments,
+			emptyMlflowModels,
 			emptySqlDashboards,
 			emptySqlEndpoints,
 			emptySqlQueries,
@@ -559,8 +626,16 @@ func TestImportingNoResourcesError(t *testing.T) {
 				},
 			},
 			emptyRepos,
+			emptyUcCatalogs,
+			emptyShares,
+			emptyRecipients,
+			emptyProviders,
+			emptyUcStorageCredentials,
+			emptyUcExternalLocations,
 			emptyModelServing,
 			emptyMlflowWebhooks,
+			emptyMlflowExperiments,
+			emptyMlflowModels,
 			emptyWorkspaceConf,
 			emptyInstancePools,
 			emptyClusterPolicies,
@@ -1497,6 +1572,64 @@ func TestImportingRepos(t *testing.T) {
 		})
 }
 
+func TestImportingReposGraphOnly(t *testing.T) {
+	resp := repos.ReposInformation{
+		ID:           121232342,
+		Url:          "https://github.com/user/test.git",
+		Provider:     "gitHub",
+		Path:         "/Repos/user@domain/test",
+		HeadCommitID: "1124323423abc23424",
+		Branch:       "releases",
+	}
+
+	qa.HTTPFixturesApply(t,
+		[]qa.HTTPFixture{
+			meAdminFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/repos?",
+				Response: repos.ReposListResponse{
+					Repos: []repos.ReposInformation{
+						resp,
+					},
+				},
+			},
+			emptyGitCredentials,
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/repos/121232342",
+				Response: resp,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/permissions/repos/121232342",
+				Response: getJSONObject("test-data/get-repo-permissions.json"),
+			},
+		},
+		func(ctx context.Context, client *common.DatabricksClient) {
+			tmpDir := fmt.Sprintf("/tmp/tf-%s", qa.RandomName())
+			defer os.RemoveAll(tmpDir)
+
+			ic := newImportContext(client)
+			ic.Directory = tmpDir
+			ic.listing = "repos"
+			ic.services = "repos"
+			ic.exportGraph = true
+			ic.graphOnly = true
+
+			err := ic.Run()
+			assert.NoError(t, err)
+
+			content, err := os.ReadFile(tmpDir + "/graph.json")
+			require.NoError(t, err)
+			assert.Contains(t, string(content), "databricks_repo.user_domain_test_")
+			for _, name := range []string{"repos.tf", "import.sh"} {
+				_, err = os.Stat(tmpDir + "/" + name)
+				assert.True(t, os.IsNotExist(err), name)
+			}
+		})
+}
+
 func TestImportingIPAccessLists(t *testing.T) {
 	resp := settings.IpAccessListInfo{
 		ListId:       "123",
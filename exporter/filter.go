@@ -0,0 +1,91 @@
+package exporter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// initFilters parses command-line filters that are applied to objects during listing
+func (ic *importContext) initFilters() (err error) {
+	if ic.includeRegexStr != "" {
+		ic.includeRegex, err = regexp.Compile(ic.includeRegexStr)
+		if err != nil {
+			return fmt.Errorf("can't parse -includeRegex '%s': %w", ic.includeRegexStr, err)
+		}
+	}
+	if ic.excludeRegexStr != "" {
+		ic.excludeRegex, err = regexp.Compile(ic.excludeRegexStr)
+		if err != nil {
+			return fmt.Errorf("can't parse -excludeRegex '%s': %w", ic.excludeRegexStr, err)
+		}
+	}
+	ic.owners = map[string]bool{}
+	for _, owner := range strings.Split(ic.ownersStr, ",") {
+		owner = strings.TrimSpace(owner)
+		if owner != "" {
+			ic.owners[strings.ToLower(owner)] = true
+		}
+	}
+	ic.tags = map[string]string{}
+	for _, tag := range strings.Split(ic.tagsStr, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(tag, "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return fmt.Errorf("tag filter '%s' should be in form of key=value or key", tag)
+		}
+		ic.tags[key] = strings.TrimSpace(value)
+	}
+	return nil
+}
+
+// MatchesOwner checks if the owner (creator) of the object is in the list of owners specified with `-owners`.
+// Objects are always matched when the owner filter isn't specified. Objects with unknown owner are matched
+// only with `-includeUnknownOwners`
+func (ic *importContext) MatchesOwner(owner string) bool {
+	if len(ic.owners) == 0 {
+		return true
+	}
+	if owner == "" {
+		return ic.unknownOwners
+	}
+	return ic.owners[strings.ToLower(owner)]
+}
+
+// MatchesTags checks that object has all tags specified with `-tags`. Tag specified without value
+// matches any value of the tag
+func (ic *importContext) MatchesTags(tags map[string]string) bool {
+	for key, value := range ic.tags {
+		v, ok := tags[key]
+		if !ok || (value != "" && v != value) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchesObject combines name, owner & tags filters for objects that expose such metadata
+func (ic *importContext) MatchesObject(name, owner string, tags map[string]string) bool {
+	return ic.MatchesName(name) && ic.MatchesOwner(owner) && ic.MatchesTags(tags)
+}
+
+// dbsqlOwnerAndTags extracts owner's email & tags from the listing of SQL queries or dashboards.
+// Tags of SQL objects don't have values, so they are returned with empty value
+func dbsqlOwnerAndTags(q map[string]any) (owner string, tags map[string]string) {
+	if user, ok := q["user"].(map[string]any); ok {
+		owner, _ = user["email"].(string)
+	}
+	tags = map[string]string{}
+	if qtags, ok := q["tags"].([]any); ok {
+		for _, t := range qtags {
+			if s, ok := t.(string); ok {
+				tags[s] = ""
+			}
+		}
+	}
+	return
+}
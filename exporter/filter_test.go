@@ -0,0 +1,179 @@
+package exporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/clusters"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/jobs"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitFilters(t *testing.T) {
+	ic := importContextForTest()
+	ic.includeRegexStr = "^team_"
+	ic.excludeRegexStr = "_tmp$"
+	ic.ownersStr = "Alice@example.com, bob@example.com,"
+	ic.tagsStr = "team=data, cost-center"
+	require.NoError(t, ic.initFilters())
+	assert.Equal(t, map[string]bool{"alice@example.com": true, "bob@example.com": true}, ic.owners)
+	assert.Equal(t, map[string]string{"team": "data", "cost-center": ""}, ic.tags)
+
+	assert.True(t, ic.MatchesName("team_etl"))
+	assert.False(t, ic.MatchesName("team_etl_tmp"))
+	assert.False(t, ic.MatchesName("etl"))
+
+	assert.True(t, ic.MatchesOwner("alice@example.com"))
+	assert.False(t, ic.MatchesOwner("eve@example.com"))
+	// objects without owner information are filtered, unless they are explicitly included
+	assert.False(t, ic.MatchesOwner(""))
+	ic.unknownOwners = true
+	assert.True(t, ic.MatchesOwner(""))
+
+	assert.True(t, ic.MatchesTags(map[string]string{"team": "data", "cost-center": "123"}))
+	assert.False(t, ic.MatchesTags(map[string]string{"team": "ml", "cost-center": "123"}))
+	assert.False(t, ic.MatchesTags(map[string]string{"team": "data"}))
+}
+
+func TestInitFiltersErrors(t *testing.T) {
+	ic := importContextForTest()
+	ic.includeRegexStr = "("
+	assert.ErrorContains(t, ic.initFilters(), "can't parse -includeRegex '('")
+
+	ic = importContextForTest()
+	ic.excludeRegexStr = "["
+	assert.ErrorContains(t, ic.initFilters(), "can't parse -excludeRegex '['")
+
+	ic = importContextForTest()
+	ic.tagsStr = "=abc"
+	assert.EqualError(t, ic.initFilters(), "tag filter '=abc' should be in form of key=value or key")
+}
+
+func TestNoFiltersMatchEverything(t *testing.T) {
+	ic := importContextForTest()
+	require.NoError(t, ic.initFilters())
+	assert.True(t, ic.MatchesObject("abc", "", nil))
+}
+
+func TestDbsqlOwnerAndTags(t *testing.T) {
+	owner, tags := dbsqlOwnerAndTags(map[string]any{
+		"user": map[string]any{"email": "alice@example.com"},
+		"tags": []any{"team", "prod"},
+	})
+	assert.Equal(t, "alice@example.com", owner)
+	assert.Equal(t, map[string]string{"team": "", "prod": ""}, tags)
+
+	owner, tags = dbsqlOwnerAndTags(map[string]any{})
+	assert.Equal(t, "", owner)
+	assert.Equal(t, map[string]string{}, tags)
+}
+
+func TestJobListOwnerAndTagsFilters(t *testing.T) {
+	ic := importContextForTest()
+	ic.ownersStr = "alice@example.com"
+	ic.tagsStr = "team=data"
+	require.NoError(t, ic.initFilters())
+	ic.importJobs([]jobs.Job{
+		{
+			JobID:           1,
+			CreatorUserName: "alice@example.com",
+			Settings: &jobs.JobSettings{
+				Name: "matches",
+				Tags: map[string]string{"team": "data"},
+			},
+		},
+		{
+			JobID:           2,
+			CreatorUserName: "bob@example.com",
+			Settings: &jobs.JobSettings{
+				Name: "other owner",
+				Tags: map[string]string{"team": "data"},
+			},
+		},
+		{
+			JobID:           3,
+			CreatorUserName: "alice@example.com",
+			Settings: &jobs.JobSettings{
+				Name: "no tags",
+			},
+		},
+	})
+	assert.Equal(t, map[string]bool{"databricks_job[<unknown>] (id: 1)": true}, ic.testEmits)
+}
+
+func TestClusterListExcludeRegexAndOwner(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/list",
+			Response: clusters.ClusterList{
+				Clusters: []clusters.ClusterInfo{
+					{
+						ClusterID:       "a",
+						ClusterName:     "shared",
+						CreatorUserName: "alice@example.com",
+					},
+					{
+						ClusterID:       "b",
+						ClusterName:     "shared-tmp",
+						CreatorUserName: "alice@example.com",
+					},
+					{
+						ClusterID:       "c",
+						ClusterName:     "other",
+						CreatorUserName: "bob@example.com",
+					},
+					{
+						ClusterID:   "d",
+						ClusterName: "creator is deleted",
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		ic := importContextForTest()
+		ic.Client = client
+		ic.Context = ctx
+		ic.excludeRegexStr = "-tmp$"
+		ic.ownersStr = "alice@example.com"
+		require.NoError(t, ic.initFilters())
+		err := resourcesMap["databricks_cluster"].List(ic)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]bool{"databricks_cluster[<unknown>] (id: a)": true}, ic.testEmits)
+	})
+}
+
+func TestInstancePoolsListWithTags(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/instance-pools/list",
+			Response: compute.ListInstancePools{
+				InstancePools: []compute.InstancePoolAndStats{
+					{
+						InstancePoolName: "test",
+						InstancePoolId:   "test",
+						CustomTags:       map[string]string{"team": "data"},
+					},
+					{
+						InstancePoolName: "bcd",
+						InstancePoolId:   "bcd",
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		ic := importContextForTest()
+		ic.Client = client
+		ic.Context = ctx
+		ic.tagsStr = "team"
+		require.NoError(t, ic.initFilters())
+		err := resourcesMap["databricks_instance_pool"].List(ic)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]bool{"databricks_instance_pool[<unknown>] (id: test)": true}, ic.testEmits)
+	})
+}
@@ -0,0 +1,183 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+type graphNode struct {
+	// Terraform address of the resource, i.e. `databricks_job.abc` or `data.databricks_cluster_policy.abc`
+	Address    string `json:"address"`
+	Type       string `json:"type"`
+	Name       string `json:"name"`
+	ResourceID string `json:"resource_id"`
+	Mode       string `json:"mode"`
+	Service    string `json:"service"`
+}
+
+type graphEdge struct {
+	From string `json:"from"`
+	// Address of referenced resource, empty for unresolved references
+	To string `json:"to,omitempty"`
+	// Path to attribute without indices, like `task.existing_cluster_id`
+	Path string `json:"path"`
+	// Reference wasn't resolved to any of exported resources, so literal value is generated
+	Unresolved bool `json:"unresolved,omitempty"`
+	// Referenced resource isn't part of the export (i.e. it was ignored, or exported during previous runs)
+	Dangling bool `json:"dangling,omitempty"`
+	// Literal value of unresolved reference
+	Value string `json:"value,omitempty"`
+	// Resource types that may be referenced by unresolved reference
+	Candidates []string `json:"candidates,omitempty"`
+}
+
+type resourceGraph struct {
+	Nodes []graphNode `json:"nodes"`
+	Edges []graphEdge `json:"edges"`
+}
+
+func (ic *importContext) addGraphNode(r *resource, ir importable, blocks []*hclwrite.Block) {
+	mode := "resource"
+	if r.Mode == "data" {
+		mode = "data"
+	}
+	node := graphNode{
+		Type:       r.Resource,
+		Name:       r.Name,
+		ResourceID: r.ID,
+		Mode:       mode,
+		Service:    ir.Service,
+	}
+	node.Address = manifestEntry{Type: r.Resource, Name: r.Name, Mode: mode}.Address()
+	ic.graph.Nodes = append(ic.graph.Nodes, node)
+
+	candidates := map[string][]string{}
+	for _, ref := range ir.Depends {
+		if ref.Variable || ref.File {
+			continue
+		}
+		candidates[ref.Path] = append(candidates[ref.Path], ref.Resource)
+	}
+	for _, block := range blocks {
+		walkBodyAttributes(block.Body(), []string{}, func(path string, attr *hclwrite.Attribute) {
+			traversals := attr.Expr().Variables()
+			for _, traversal := range traversals {
+				to := traversalAddress(traversal)
+				if to == "" || to == node.Address {
+					continue
+				}
+				ic.graph.Edges = append(ic.graph.Edges, graphEdge{From: node.Address, To: to, Path: path})
+			}
+			types, ok := candidates[path]
+			if !ok || len(traversals) > 0 {
+				return
+			}
+			ic.graph.Edges = append(ic.graph.Edges, graphEdge{
+				From:       node.Address,
+				Path:       path,
+				Unresolved: true,
+				Value:      strings.TrimSpace(string(attr.Expr().BuildTokens(nil).Bytes())),
+				Candidates: types,
+			})
+		})
+	}
+}
+
+func walkBodyAttributes(body *hclwrite.Body, path []string, cb func(path string, attr *hclwrite.Attribute)) {
+	attrs := body.Attributes()
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cb(strings.Join(append(path, name), "."), attrs[name])
+	}
+	for _, block := range body.Blocks() {
+		walkBodyAttributes(block.Body(), append(path, block.Type()), cb)
+	}
+}
+
+// traversalAddress returns address of the referenced resource or data source, or empty string for other references
+func traversalAddress(traversal *hclwrite.Traversal) string {
+	parts := strings.Split(strings.TrimSpace(string(traversal.BuildTokens(nil).Bytes())), ".")
+	if parts[0] == "data" && len(parts) >= 3 {
+		return strings.Join(parts[0:3], ".")
+	}
+	if strings.HasPrefix(parts[0], "databricks_") && len(parts) >= 2 {
+		return strings.Join(parts[0:2], ".")
+	}
+	return ""
+}
+
+// markDanglingEdges marks references to resources that aren't part of the graph
+func (g *resourceGraph) markDanglingEdges() {
+	addresses := map[string]bool{}
+	for _, n := range g.Nodes {
+		addresses[n.Address] = true
+	}
+	for i, e := range g.Edges {
+		if e.To != "" && !addresses[e.To] {
+			g.Edges[i].Dangling = true
+		}
+	}
+}
+
+func (g *resourceGraph) toDot() string {
+	var sb strings.Builder
+	sb.WriteString("digraph exporter {\n  rankdir=LR;\n  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		sb.WriteString(fmt.Sprintf("  %s [label=%s];\n", strconv.Quote(n.Address),
+			strconv.Quote(n.Address+"\nid: "+n.ResourceID)))
+	}
+	for _, e := range g.Edges {
+		switch {
+		case e.Unresolved:
+			to := "unresolved: " + strings.Join(e.Candidates, ",") + " " + e.Value
+			sb.WriteString(fmt.Sprintf("  %s [label=%s, style=dashed, color=red];\n", strconv.Quote(to),
+				strconv.Quote(strings.Join(e.Candidates, "\n")+"\n"+e.Value)))
+			sb.WriteString(fmt.Sprintf("  %s -> %s [label=%s, style=dashed, color=red];\n",
+				strconv.Quote(e.From), strconv.Quote(to), strconv.Quote(e.Path)))
+		case e.Dangling:
+			sb.WriteString(fmt.Sprintf("  %s -> %s [label=%s, color=orange];\n",
+				strconv.Quote(e.From), strconv.Quote(e.To), strconv.Quote(e.Path)))
+		default:
+			sb.WriteString(fmt.Sprintf("  %s -> %s [label=%s];\n",
+				strconv.Quote(e.From), strconv.Quote(e.To), strconv.Quote(e.Path)))
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func (ic *importContext) generateGraph() error {
+	ic.graph.markDanglingEdges()
+	data, err := json.MarshalIndent(ic.graph, "", "  ")
+	if err != nil {
+		return err
+	}
+	jsonFileName := fmt.Sprintf("%s/graph.json", ic.Directory)
+	if err = os.WriteFile(jsonFileName, data, 0644); err != nil {
+		return err
+	}
+	dotFileName := fmt.Sprintf("%s/graph.dot", ic.Directory)
+	if err = os.WriteFile(dotFileName, []byte(ic.graph.toDot()), 0644); err != nil {
+		return err
+	}
+	unresolved := 0
+	for _, e := range ic.graph.Edges {
+		if e.Unresolved {
+			unresolved++
+		}
+	}
+	log.Printf("[INFO] Written resource graph with %d nodes and %d edges (%d unresolved) into %s and %s",
+		len(ic.graph.Nodes), len(ic.graph.Edges), unresolved, jsonFileName, dotFileName)
+	return nil
+}
@@ -0,0 +1,128 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/commands"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestAddGraphNode(t *testing.T) {
+	ic := importContextForTest()
+	ir := importable{
+		Service: "jobs",
+		Depends: []reference{
+			{Path: "task.existing_cluster_id", Resource: "databricks_cluster"},
+			{Path: "task.notebook_task.notebook_path", Resource: "databricks_notebook"},
+			{Path: "task.notebook_task.notebook_path", Resource: "databricks_repo", Match: "path",
+				MatchType: MatchPrefix},
+			{Path: "webhook_token", Variable: true},
+		},
+	}
+	f := hclwrite.NewEmptyFile()
+	block := f.Body().AppendNewBlock("resource", []string{"databricks_job", "etl"})
+	block.Body().SetAttributeValue("webhook_token", cty.StringVal("abc"))
+	task := block.Body().AppendNewBlock("task", nil).Body()
+	task.SetAttributeTraversal("existing_cluster_id", hcl.Traversal{
+		hcl.TraverseRoot{Name: "databricks_cluster"},
+		hcl.TraverseAttr{Name: "shared"},
+		hcl.TraverseAttr{Name: "id"},
+	})
+	task.AppendNewBlock("notebook_task", nil).Body().SetAttributeValue("notebook_path",
+		cty.StringVal("/Shared/etl"))
+
+	ic.addGraphNode(&resource{
+		Resource: "databricks_job",
+		Name:     "etl",
+		ID:       "123",
+	}, ir, []*hclwrite.Block{block})
+
+	assert.Equal(t, []graphNode{
+		{
+			Address:    "databricks_job.etl",
+			Type:       "databricks_job",
+			Name:       "etl",
+			ResourceID: "123",
+			Mode:       "resource",
+			Service:    "jobs",
+		},
+	}, ic.graph.Nodes)
+	assert.Equal(t, []graphEdge{
+		{
+			From: "databricks_job.etl",
+			To:   "databricks_cluster.shared",
+			Path: "task.existing_cluster_id",
+		},
+		{
+			From:       "databricks_job.etl",
+			Path:       "task.notebook_task.notebook_path",
+			Unresolved: true,
+			Value:      `"/Shared/etl"`,
+			Candidates: []string{"databricks_notebook", "databricks_repo"},
+		},
+	}, ic.graph.Edges)
+
+	ic.graph.markDanglingEdges()
+	assert.True(t, ic.graph.Edges[0].Dangling)
+}
+
+func TestGraphToDot(t *testing.T) {
+	g := resourceGraph{
+		Nodes: []graphNode{
+			{Address: "databricks_job.etl", ResourceID: "123"},
+			{Address: "databricks_cluster.shared", ResourceID: "c1"},
+		},
+		Edges: []graphEdge{
+			{From: "databricks_job.etl", To: "databricks_cluster.shared", Path: "task.existing_cluster_id"},
+			{From: "databricks_job.etl", To: "databricks_instance_pool.pool", Path: "task.new_cluster.instance_pool_id"},
+			{From: "databricks_job.etl", Path: "task.notebook_task.notebook_path", Unresolved: true,
+				Value: `"/Shared/etl"`, Candidates: []string{"databricks_notebook"}},
+		},
+	}
+	g.markDanglingEdges()
+	assert.False(t, g.Edges[0].Dangling)
+	assert.True(t, g.Edges[1].Dangling)
+	assert.Equal(t, commands.TrimLeadingWhitespace(`
+	digraph exporter {
+	  rankdir=LR;
+	  node [shape=box];
+	  "databricks_job.etl" [label="databricks_job.etl\nid: 123"];
+	  "databricks_cluster.shared" [label="databricks_cluster.shared\nid: c1"];
+	  "databricks_job.etl" -> "databricks_cluster.shared" [label="task.existing_cluster_id"];
+	  "databricks_job.etl" -> "databricks_instance_pool.pool" [label="task.new_cluster.instance_pool_id", color=orange];
+	  "unresolved: databricks_notebook \"/Shared/etl\"" [label="databricks_notebook\n\"/Shared/etl\"", style=dashed, color=red];
+	  "databricks_job.etl" -> "unresolved: databricks_notebook \"/Shared/etl\"" [label="task.notebook_task.notebook_path", style=dashed, color=red];
+	}
+	`), g.toDot())
+}
+
+func TestGenerateGraph(t *testing.T) {
+	ic := importContextForTest()
+	ic.Directory = fmt.Sprintf("/tmp/tf-%s", qa.RandomName())
+	require.NoError(t, os.MkdirAll(ic.Directory, 0755))
+	defer os.RemoveAll(ic.Directory)
+	ic.graph = resourceGraph{
+		Nodes: []graphNode{{Address: "databricks_job.etl", ResourceID: "123"}},
+		Edges: []graphEdge{{From: "databricks_job.etl", To: "databricks_cluster.shared", Path: "existing_cluster_id"}},
+	}
+	require.NoError(t, ic.generateGraph())
+
+	content, err := os.ReadFile(ic.Directory + "/graph.json")
+	require.NoError(t, err)
+	var g resourceGraph
+	require.NoError(t, json.Unmarshal(content, &g))
+	assert.Len(t, g.Nodes, 1)
+	require.Len(t, g.Edges, 1)
+	assert.True(t, g.Edges[0].Dangling)
+
+	_, err = os.Stat(ic.Directory + "/graph.dot")
+	assert.NoError(t, err)
+}
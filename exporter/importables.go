@@ -14,14 +14,18 @@ import (
 
 	"golang.org/x/exp/slices"
 
+	sdk_catalog "github.com/databricks/databricks-sdk-go/service/catalog"
 	"github.com/databricks/databricks-sdk-go/service/compute"
 	"github.com/databricks/databricks-sdk-go/service/iam"
 	sdk_jobs "github.com/databricks/databricks-sdk-go/service/jobs"
 	"github.com/databricks/databricks-sdk-go/service/ml"
 	"github.com/databricks/databricks-sdk-go/service/settings"
+	sdk_sharing "github.com/databricks/databricks-sdk-go/service/sharing"
+	"github.com/databricks/terraform-provider-databricks/catalog"
 	"github.com/databricks/terraform-provider-databricks/clusters"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/jobs"
+	"github.com/databricks/terraform-provider-databricks/mws"
 	"github.com/databricks/terraform-provider-databricks/permissions"
 	"github.com/databricks/terraform-provider-databricks/pipelines"
 	"github.com/databricks/terraform-provider-databricks/repos"
@@ -48,10 +52,12 @@ var (
 	jobClustersRegex             = regexp.MustCompile(`^((job_cluster|task)\.[0-9]+\.new_cluster\.[0-9]+\.)`)
 	dltClusterRegex              = regexp.MustCompile(`^(cluster\.[0-9]+\.)`)
 	predefinedClusterPolicies    = []string{"Personal Compute", "Job Compute", "Power User Compute", "Shared Compute"}
+	builtInCatalogs              = []string{"hive_metastore", "samples", "system"}
 	secretPathRegex              = regexp.MustCompile(`^\{\{secrets\/([^\/]+)\/([^}]+)\}\}$`)
 	sqlParentRegexp              = regexp.MustCompile(`^folders/(\d+)$`)
 	dltDefaultStorageRegex       = regexp.MustCompile(`^dbfs:/pipelines/[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
 	ignoreIdeFolderRegex         = regexp.MustCompile(`^/Users/[^/]+/\.ide/.*$`)
+	parentDirectoryRegex         = regexp.MustCompile(`^(.+)/[^/]+$`)
 	fileExtensionLanguageMapping = map[string]string{
 		"SCALA":  ".scala",
 		"PYTHON": ".py",
@@ -66,6 +72,15 @@ var (
 	}
 )
 
+// resource types of objects that could be shared via Delta Sharing
+var sharedObjectResources = map[string]string{
+	"TABLE":  "databricks_sql_table",
+	"VIEW":   "databricks_sql_table",
+	"SCHEMA": "databricks_schema",
+	"VOLUME": "databricks_volume",
+	"MODEL":  "databricks_registered_model",
+}
+
 func generateMountBody(ic *importContext, body *hclwrite.Body, r *resource) error {
 	mount := ic.mountMap[r.ID]
 
@@ -204,7 +219,7 @@ var resourcesMap map[string]importable = map[string]importable{
 				return err
 			}
 			for i, pool := range pools {
-				if !ic.MatchesName(pool.InstancePoolName) {
+				if !ic.MatchesName(pool.InstancePoolName) || !ic.MatchesTags(pool.CustomTags) {
 					continue
 				}
 				ic.Emit(&resource{
@@ -313,8 +328,8 @@ var resourcesMap map[string]importable = map[string]importable{
 					log.Printf("[INFO] Skipping terraform-specific cluster %s", c.ClusterName)
 					continue
 				}
-				if !ic.MatchesName(c.ClusterName) {
-					log.Printf("[INFO] Skipping %s because it doesn't match %s", c.ClusterName, ic.match)
+				if !ic.MatchesObject(c.ClusterName, c.CreatorUserName, c.CustomTags) {
+					log.Printf("[INFO] Skipping %s because it doesn't match filters", c.ClusterName)
 					continue
 				}
 				if c.LastActivityTime > 0 && c.LastActivityTime < lastActiveMs {
@@ -969,7 +984,7 @@ var resourcesMap map[string]importable = map[string]importable{
 			{Path: "sql_alert_id", Resource: "databricks_sql_alert"},
 			{Path: "sql_dashboard_id", Resource: "databricks_sql_dashboard"},
 			{Path: "sql_endpoint_id", Resource: "databricks_sql_endpoint"},
-			{Path: "registered_model_id", Resource: "databricks_mlflow_model"},
+			{Path: "registered_model_id", Resource: "databricks_mlflow_model", Match: "registered_model_id"},
 			{Path: "experiment_id", Resource: "databricks_mlflow_experiment"},
 			{Path: "repo_id", Resource: "databricks_repo"},
 			{Path: "directory_id", Resource: "databricks_directory", Match: "object_id"},
@@ -1491,7 +1506,8 @@ var resourcesMap map[string]importable = map[string]importable{
 			updatedSinceStr := ic.getUpdatedSinceStr()
 			for i, q := range qs {
 				name := q["name"].(string)
-				if !ic.MatchesName(name) {
+				owner, tags := dbsqlOwnerAndTags(q)
+				if !ic.MatchesObject(name, owner, tags) {
 					continue
 				}
 				updatedAt := q["updated_at"].(string)
@@ -1576,7 +1592,13 @@ var resourcesMap map[string]importable = map[string]importable{
 				return err
 			}
 			for i, q := range endpointsList.Endpoints {
-				if !ic.MatchesName(q.Name) {
+				tags := map[string]string{}
+				if q.Tags != nil {
+					for _, tag := range q.Tags.CustomTags {
+						tags[tag.Key] = tag.Value
+					}
+				}
+				if !ic.MatchesName(q.Name) || !ic.MatchesTags(tags) {
 					continue
 				}
 				ic.Emit(&resource{
@@ -1645,7 +1667,8 @@ var resourcesMap map[string]importable = map[string]importable{
 			updatedSinceStr := ic.getUpdatedSinceStr()
 			for i, q := range qs {
 				name := q["name"].(string)
-				if !ic.MatchesName(name) {
+				owner, tags := dbsqlOwnerAndTags(q)
+				if !ic.MatchesObject(name, owner, tags) {
 					continue
 				}
 				updatedAt := q["updated_at"].(string)
@@ -1786,7 +1809,11 @@ var resourcesMap map[string]importable = map[string]importable{
 			}
 			for i, alert := range alerts {
 				name := alert.Name
-				if !ic.MatchesName(name) {
+				owner := ""
+				if alert.User != nil {
+					owner = alert.User.Email
+				}
+				if !ic.MatchesName(name) || !ic.MatchesOwner(owner) {
 					continue
 				}
 				if ic.incremental && alert.UpdatedAt < updatedSinceStr {
@@ -1854,7 +1881,7 @@ var resourcesMap map[string]importable = map[string]importable{
 			}
 			updatedSinceMs := ic.getUpdatedSinceMs()
 			for i, q := range pipelinesList {
-				if !ic.MatchesName(q.Name) {
+				if !ic.MatchesName(q.Name) || !ic.MatchesOwner(q.CreatorUserName) {
 					continue
 				}
 				if ic.incremental {
@@ -2057,8 +2084,17 @@ var resourcesMap map[string]importable = map[string]importable{
 					Name:     "serving_endpoint_" + ic.Importables["databricks_model_serving"].Name(ic, r.Data),
 				})
 			}
+			servedModels := r.Data.Get("config.0.served_models").([]any)
+			for _, sm := range servedModels {
+				modelName, _ := sm.(map[string]any)["model_name"].(string)
+				ic.emitServedModel(modelName)
+			}
 			return nil
 		},
+		Depends: []reference{
+			{Path: "config.served_models.model_name", Resource: "databricks_registered_model"},
+			{Path: "config.served_models.model_name", Resource: "databricks_mlflow_model"},
+		},
 		ShouldOmitField: func(ic *importContext, pathString string, as *schema.Schema, d *schema.ResourceData) bool {
 			if pathString == "config.0.traffic_config" ||
 				(strings.HasPrefix(pathString, "config.0.served_models.") &&
@@ -2104,6 +2140,12 @@ var resourcesMap map[string]importable = map[string]importable{
 						ID:       webhook.JobSpec.JobId,
 					})
 				}
+				if webhook.ModelName != "" {
+					ic.Emit(&resource{
+						Resource: "databricks_mlflow_model",
+						ID:       webhook.ModelName,
+					})
+				}
 				if offset%50 == 0 {
 					log.Printf("[INFO] Scanned %d of %d MLflow webhooks", offset+1, len(webhooks))
 				}
@@ -2113,10 +2155,174 @@ var resourcesMap map[string]importable = map[string]importable{
 		Depends: []reference{
 			{Path: "job_spec.job_id", Resource: "databricks_job"},
 			{Path: "job_spec.access_token", Variable: true},
+			{Path: "model_name", Resource: "databricks_mlflow_model"},
 			// We can enable it, but we don't know if authorization is set or not because API doesn't return it
 			// {Path: "http_url_spec.authorization", Variable: true},
 		},
 	},
+	"databricks_mlflow_experiment": {
+		WorkspaceLevel: true,
+		Service:        "mlflow-experiments",
+		Name: func(ic *importContext, d *schema.ResourceData) string {
+			name := d.Get("name").(string)
+			if name == "" {
+				return d.Id()
+			}
+			return nameNormalizationRegex.ReplaceAllString(strings.TrimPrefix(name, "/"), "_") + "_" + d.Id()
+		},
+		List: func(ic *importContext) error {
+			w, err := ic.Client.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			experiments, err := w.Experiments.ListExperimentsAll(ic.Context, ml.ListExperimentsRequest{})
+			if err != nil {
+				return err
+			}
+			updatedSinceMs := ic.getUpdatedSinceMs()
+			for offset, experiment := range experiments {
+				tags := map[string]string{}
+				for _, tag := range experiment.Tags {
+					tags[tag.Key] = tag.Value
+				}
+				// experiments of notebooks are created together with notebooks & can't be managed separately
+				if tags["mlflow.experimentType"] == "NOTEBOOK" {
+					log.Printf("[DEBUG] skipping notebook experiment '%s'", experiment.Name)
+					continue
+				}
+				if !ic.MatchesName(experiment.Name) || !ic.MatchesOwner(tags["mlflow.ownerEmail"]) {
+					continue
+				}
+				if ic.incremental && experiment.LastUpdateTime < updatedSinceMs {
+					log.Printf("[DEBUG] skipping MLflow experiment '%s' that was modified at %d (last active=%d)",
+						experiment.Name, experiment.LastUpdateTime, updatedSinceMs)
+					continue
+				}
+				ic.Emit(&resource{
+					Resource: "databricks_mlflow_experiment",
+					ID:       experiment.ExperimentId,
+				})
+				if offset%50 == 0 {
+					log.Printf("[INFO] Scanned %d of %d MLflow experiments", offset+1, len(experiments))
+				}
+			}
+			return nil
+		},
+		Import: func(ic *importContext, r *resource) error {
+			name := r.Data.Get("name").(string)
+			ic.emitUserOrServicePrincipalForPath(name, "/Users")
+			if res := parentDirectoryRegex.FindStringSubmatch(name); res != nil {
+				ic.Emit(&resource{
+					Resource: "databricks_directory",
+					ID:       res[1],
+				})
+			}
+			if ic.meAdmin {
+				ic.Emit(&resource{
+					Resource: "databricks_permissions",
+					ID:       fmt.Sprintf("/experiments/%s", r.ID),
+					Name:     "experiment_" + ic.Importables["databricks_mlflow_experiment"].Name(ic, r.Data),
+				})
+			}
+			return nil
+		},
+		Depends: []reference{
+			{Path: "name", Resource: "databricks_user", Match: "home", MatchType: MatchPrefix},
+			{Path: "name", Resource: "databricks_service_principal", Match: "home", MatchType: MatchPrefix},
+			{Path: "name", Resource: "databricks_directory", Match: "path", MatchType: MatchRegexp,
+				Regexp: parentDirectoryRegex},
+		},
+	},
+	"databricks_mlflow_model": {
+		WorkspaceLevel: true,
+		Service:        "mlflow-models",
+		Name: func(ic *importContext, d *schema.ResourceData) string {
+			return nameNormalizationRegex.ReplaceAllString(d.Id(), "_")
+		},
+		List: func(ic *importContext) error {
+			w, err := ic.Client.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			models, err := w.ModelRegistry.ListModelsAll(ic.Context, ml.ListModelsRequest{})
+			if err != nil {
+				return err
+			}
+			updatedSinceMs := ic.getUpdatedSinceMs()
+			for offset, model := range models {
+				tags := map[string]string{}
+				for _, tag := range model.Tags {
+					tags[tag.Key] = tag.Value
+				}
+				if !ic.MatchesObject(model.Name, model.UserId, tags) {
+					continue
+				}
+				if ic.incremental && model.LastUpdatedTimestamp < updatedSinceMs {
+					log.Printf("[DEBUG] skipping MLflow model '%s' that was modified at %d (last active=%d)",
+						model.Name, model.LastUpdatedTimestamp, updatedSinceMs)
+					continue
+				}
+				ic.Emit(&resource{
+					Resource: "databricks_mlflow_model",
+					ID:       model.Name,
+				})
+				if offset%50 == 0 {
+					log.Printf("[INFO] Scanned %d of %d MLflow models", offset+1, len(models))
+				}
+			}
+			return nil
+		},
+		Import: func(ic *importContext, r *resource) error {
+			if ic.meAdmin {
+				ic.Emit(&resource{
+					Resource: "databricks_permissions",
+					ID:       fmt.Sprintf("/registered-models/%s", r.Data.Get("registered_model_id").(string)),
+					Name:     "mlflow_model_" + ic.Importables["databricks_mlflow_model"].Name(ic, r.Data),
+				})
+			}
+			w, err := ic.Client.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			webhooks, err := w.ModelRegistry.ListWebhooksAll(ic.Context, ml.ListWebhooksRequest{
+				ModelName: r.ID,
+			})
+			if err != nil {
+				return err
+			}
+			for _, webhook := range webhooks {
+				ic.Emit(&resource{
+					Resource: "databricks_mlflow_webhook",
+					ID:       webhook.Id,
+				})
+			}
+			return nil
+		},
+	},
+	"databricks_registered_model": {
+		WorkspaceLevel: true,
+		Service:        "uc",
+		Name: func(ic *importContext, d *schema.ResourceData) string {
+			return nameNormalizationRegex.ReplaceAllString(d.Id(), "_")
+		},
+		Import: func(ic *importContext, r *resource) error {
+			ic.Emit(&resource{
+				Resource: "databricks_catalog",
+				ID:       r.Data.Get("catalog_name").(string),
+			})
+			ic.Emit(&resource{
+				Resource: "databricks_schema",
+				ID:       r.Data.Get("catalog_name").(string) + "." + r.Data.Get("schema_name").(string),
+			})
+			ic.emitUcGrants("model", r.ID)
+			return nil
+		},
+		Depends: []reference{
+			{Path: "catalog_name", Resource: "databricks_catalog"},
+			{Path: "schema_name", Resource: "databricks_schema", Match: "name",
+				IsValidApproximation: isMatchingCatalog},
+		},
+	},
 	"databricks_access_control_rule_set": {
 		AccountLevel: true,
 		Service:      "access",
@@ -2188,4 +2394,678 @@ var resourcesMap map[string]importable = map[string]importable{
 			return len(rule.GrantRules) == 0
 		},
 	},
+	"databricks_catalog": {
+		WorkspaceLevel: true,
+		Service:        "uc",
+		Name: func(ic *importContext, d *schema.ResourceData) string {
+			return d.Id()
+		},
+		List: func(ic *importContext) error {
+			w, err := ic.Client.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			catalogs, err := w.Catalogs.ListAll(ic.Context)
+			if err != nil {
+				return err
+			}
+			for offset, c := range catalogs {
+				if c.CatalogType == sdk_catalog.CatalogTypeSystemCatalog || slices.Contains(builtInCatalogs, c.Name) {
+					log.Printf("[DEBUG] Skipping built-in catalog %s", c.Name)
+					continue
+				}
+				if !ic.MatchesName(c.Name) {
+					log.Printf("[DEBUG] Catalog %s doesn't match %s filter", c.Name, ic.match)
+					continue
+				}
+				ic.Emit(&resource{
+					Resource: "databricks_catalog",
+					ID:       c.Name,
+				})
+				log.Printf("[INFO] Scanned %d of %d catalogs", offset+1, len(catalogs))
+			}
+			return nil
+		},
+		Import: func(ic *importContext, r *resource) error {
+			ic.emitUcGrants("catalog", r.ID)
+			// schemas of the Delta Sharing and foreign catalogs aren't managed separately
+			if r.Data.Get("share_name").(string) != "" || r.Data.Get("connection_name").(string) != "" {
+				return nil
+			}
+			w, err := ic.Client.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			schemas, err := w.Schemas.ListAll(ic.Context, sdk_catalog.ListSchemasRequest{
+				CatalogName: r.ID,
+			})
+			if err != nil {
+				return err
+			}
+			for _, s := range schemas {
+				if s.Name == "information_schema" {
+					continue
+				}
+				ic.Emit(&resource{
+					Resource: "databricks_schema",
+					ID:       s.FullName,
+				})
+			}
+			return nil
+		},
+		Depends: []reference{
+			{Path: "storage_root", Resource: "databricks_external_location", Match: "url", MatchType: MatchPrefix},
+		},
+	},
+	"databricks_schema": {
+		WorkspaceLevel: true,
+		Service:        "uc",
+		Name: func(ic *importContext, d *schema.ResourceData) string {
+			return d.Id()
+		},
+		Import: func(ic *importContext, r *resource) error {
+			catalogName := r.Data.Get("catalog_name").(string)
+			schemaName := r.Data.Get("name").(string)
+			ic.Emit(&resource{
+				Resource: "databricks_catalog",
+				ID:       catalogName,
+			})
+			ic.emitUcGrants("schema", r.ID)
+			w, err := ic.Client.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			tables, err := w.Tables.ListAll(ic.Context, sdk_catalog.ListTablesRequest{
+				CatalogName: catalogName,
+				SchemaName:  schemaName,
+			})
+			if err != nil {
+				return err
+			}
+			for _, t := range tables {
+				switch t.TableType {
+				case sdk_catalog.TableTypeManaged, sdk_catalog.TableTypeExternal, sdk_catalog.TableTypeView:
+					ic.Emit(&resource{
+						Resource: "databricks_sql_table",
+						ID:       t.FullName,
+					})
+				default:
+					log.Printf("[DEBUG] Skipping table %s of unsupported type %s", t.FullName, t.TableType)
+				}
+			}
+			volumes, err := w.Volumes.ListAll(ic.Context, sdk_catalog.ListVolumesRequest{
+				CatalogName: catalogName,
+				SchemaName:  schemaName,
+			})
+			if err != nil {
+				return err
+			}
+			for _, v := range volumes {
+				ic.Emit(&resource{
+					Resource: "databricks_volume",
+					ID:       v.FullName,
+				})
+			}
+			models, err := w.RegisteredModels.ListAll(ic.Context, sdk_catalog.ListRegisteredModelsRequest{
+				CatalogName: catalogName,
+				SchemaName:  schemaName,
+			})
+			if err != nil {
+				return err
+			}
+			for _, m := range models {
+				ic.Emit(&resource{
+					Resource: "databricks_registered_model",
+					ID:       m.FullName,
+				})
+			}
+			return nil
+		},
+		Depends: []reference{
+			{Path: "catalog_name", Resource: "databricks_catalog"},
+			{Path: "storage_root", Resource: "databricks_external_location", Match: "url", MatchType: MatchPrefix},
+		},
+	},
+	"databricks_volume": {
+		WorkspaceLevel: true,
+		Service:        "uc",
+		Name: func(ic *importContext, d *schema.ResourceData) string {
+			return d.Id()
+		},
+		Import: func(ic *importContext, r *resource) error {
+			ic.emitUcGrants("volume", r.ID)
+			return nil
+		},
+		ShouldOmitField: func(ic *importContext, pathString string, as *schema.Schema, d *schema.ResourceData) bool {
+			if pathString == "storage_location" {
+				return d.Get("volume_type").(string) == "MANAGED"
+			}
+			return defaultShouldOmitFieldFunc(ic, pathString, as, d)
+		},
+		Depends: []reference{
+			{Path: "catalog_name", Resource: "databricks_catalog"},
+			{Path: "schema_name", Resource: "databricks_schema", Match: "name",
+				IsValidApproximation: isMatchingCatalog},
+			{Path: "storage_location", Resource: "databricks_external_location", Match: "url", MatchType: MatchPrefix},
+		},
+	},
+	"databricks_sql_table": {
+		WorkspaceLevel: true,
+		Service:        "uc",
+		Name: func(ic *importContext, d *schema.ResourceData) string {
+			return d.Id()
+		},
+		Import: func(ic *importContext, r *resource) error {
+			ic.emitUcGrants("table", r.ID)
+			return nil
+		},
+		ShouldOmitField: func(ic *importContext, pathString string, as *schema.Schema, d *schema.ResourceData) bool {
+			tableType := d.Get("table_type").(string)
+			switch pathString {
+			case "storage_location":
+				return tableType != "EXTERNAL"
+			case "column":
+				// columns are computed attribute, but we need them to re-create managed & external tables
+				return tableType == "VIEW"
+			}
+			return defaultShouldOmitFieldFunc(ic, pathString, as, d)
+		},
+		Depends: []reference{
+			{Path: "catalog_name", Resource: "databricks_catalog"},
+			{Path: "schema_name", Resource: "databricks_schema", Match: "name",
+				IsValidApproximation: isMatchingCatalog},
+			{Path: "storage_credential_name", Resource: "databricks_storage_credential"},
+			{Path: "storage_location", Resource: "databricks_external_location", Match: "url", MatchType: MatchPrefix},
+		},
+	},
+	"databricks_storage_credential": {
+		WorkspaceLevel: true,
+		Service:        "uc",
+		Name: func(ic *importContext, d *schema.ResourceData) string {
+			return d.Id()
+		},
+		List: func(ic *importContext) error {
+			w, err := ic.Client.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			credentials, err := w.StorageCredentials.ListAll(ic.Context)
+			if err != nil {
+				return err
+			}
+			for offset, c := range credentials {
+				if !ic.MatchesName(c.Name) {
+					log.Printf("[DEBUG] Storage credential %s doesn't match %s filter", c.Name, ic.match)
+					continue
+				}
+				ic.Emit(&resource{
+					Resource: "databricks_storage_credential",
+					ID:       c.Name,
+				})
+				log.Printf("[INFO] Scanned %d of %d storage credentials", offset+1, len(credentials))
+			}
+			return nil
+		},
+		Import: func(ic *importContext, r *resource) error {
+			ic.emitUcGrants("storage_credential", r.ID)
+			return nil
+		},
+		Depends: []reference{
+			{Path: "azure_service_principal.client_secret", Variable: true},
+			{Path: "gcp_service_account_key.private_key", Variable: true},
+		},
+	},
+	"databricks_external_location": {
+		WorkspaceLevel: true,
+		Service:        "uc",
+		Name: func(ic *importContext, d *schema.ResourceData) string {
+			return d.Id()
+		},
+		List: func(ic *importContext) error {
+			w, err := ic.Client.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			locations, err := w.ExternalLocations.ListAll(ic.Context)
+			if err != nil {
+				return err
+			}
+			for offset, l := range locations {
+				if !ic.MatchesName(l.Name) {
+					log.Printf("[DEBUG] External location %s doesn't match %s filter", l.Name, ic.match)
+					continue
+				}
+				ic.Emit(&resource{
+					Resource: "databricks_external_location",
+					ID:       l.Name,
+				})
+				log.Printf("[INFO] Scanned %d of %d external locations", offset+1, len(locations))
+			}
+			return nil
+		},
+		Import: func(ic *importContext, r *resource) error {
+			ic.Emit(&resource{
+				Resource: "databricks_storage_credential",
+				ID:       r.Data.Get("credential_name").(string),
+			})
+			ic.emitUcGrants("external_location", r.ID)
+			return nil
+		},
+		Depends: []reference{
+			{Path: "credential_name", Resource: "databricks_storage_credential"},
+		},
+	},
+	"databricks_grants": {
+		WorkspaceLevel: true,
+		Service:        "uc",
+		Name: func(ic *importContext, d *schema.ResourceData) string {
+			return d.Id()
+		},
+		Import: func(ic *importContext, r *resource) error {
+			parts := strings.SplitN(r.ID, "/", 2)
+			if len(parts) != 2 {
+				return fmt.Errorf("incorrect grants ID: %s", r.ID)
+			}
+			// securable isn't returned by the API, so we need to restore it from ID
+			if err := r.Data.Set(parts[0], parts[1]); err != nil {
+				return err
+			}
+			var grants catalog.PermissionsList
+			common.DataToStructPointer(r.Data, ic.Resources["databricks_grants"].Schema, &grants)
+			for _, grant := range grants.Assignments {
+				// shares are granted to recipients, not to users or groups
+				if parts[0] == "share" {
+					ic.Emit(&resource{
+						Resource: "databricks_recipient",
+						ID:       grant.Principal,
+					})
+					continue
+				}
+				ic.emitUserSpOrGroup(grant.Principal)
+			}
+			return nil
+		},
+		Ignore: func(ic *importContext, r *resource) bool {
+			var grants catalog.PermissionsList
+			common.DataToStructPointer(r.Data, ic.Resources["databricks_grants"].Schema, &grants)
+			return len(grants.Assignments) == 0
+		},
+		Depends: []reference{
+			{Path: "catalog", Resource: "databricks_catalog"},
+			{Path: "schema", Resource: "databricks_schema"},
+			{Path: "table", Resource: "databricks_sql_table"},
+			{Path: "volume", Resource: "databricks_volume"},
+			{Path: "model", Resource: "databricks_registered_model"},
+			{Path: "share", Resource: "databricks_share"},
+			{Path: "storage_credential", Resource: "databricks_storage_credential"},
+			{Path: "external_location", Resource: "databricks_external_location"},
+			{Path: "grant.principal", Resource: "databricks_user", Match: "user_name"},
+			{Path: "grant.principal", Resource: "databricks_group", Match: "display_name"},
+			{Path: "grant.principal", Resource: "databricks_service_principal", Match: "application_id"},
+			{Path: "grant.principal", Resource: "databricks_recipient"},
+		},
+	},
+	"databricks_share": {
+		WorkspaceLevel: true,
+		Service:        "delta-sharing",
+		Name: func(ic *importContext, d *schema.ResourceData) string {
+			return nameNormalizationRegex.ReplaceAllString(d.Id(), "_")
+		},
+		List: func(ic *importContext) error {
+			w, err := ic.Client.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			shares, err := w.Shares.ListAll(ic.Context)
+			if err != nil {
+				return err
+			}
+			for offset, share := range shares {
+				if !ic.MatchesName(share.Name) || !ic.MatchesOwner(share.Owner) {
+					log.Printf("[DEBUG] Share %s doesn't match selection filters", share.Name)
+					continue
+				}
+				ic.Emit(&resource{
+					Resource: "databricks_share",
+					ID:       share.Name,
+				})
+				log.Printf("[INFO] Scanned %d of %d shares", offset+1, len(shares))
+			}
+			return nil
+		},
+		Import: func(ic *importContext, r *resource) error {
+			var share catalog.ShareInfo
+			common.DataToStructPointer(r.Data, ic.Resources["databricks_share"].Schema, &share)
+			for _, obj := range share.Objects {
+				resourceType, ok := sharedObjectResources[obj.DataObjectType]
+				if !ok {
+					log.Printf("[WARN] Unsupported type %s of shared object %s in share %s",
+						obj.DataObjectType, obj.Name, r.ID)
+					continue
+				}
+				ic.Emit(&resource{
+					Resource: resourceType,
+					ID:       obj.Name,
+				})
+			}
+			ic.emitUcGrants("share", r.ID)
+			return nil
+		},
+		Depends: []reference{
+			{Path: "object.name", Resource: "databricks_sql_table"},
+			{Path: "object.name", Resource: "databricks_schema"},
+			{Path: "object.name", Resource: "databricks_volume"},
+			{Path: "object.name", Resource: "databricks_registered_model"},
+		},
+	},
+	"databricks_recipient": {
+		WorkspaceLevel: true,
+		Service:        "delta-sharing",
+		Name: func(ic *importContext, d *schema.ResourceData) string {
+			return nameNormalizationRegex.ReplaceAllString(d.Id(), "_")
+		},
+		List: func(ic *importContext) error {
+			w, err := ic.Client.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			recipients, err := w.Recipients.ListAll(ic.Context, sdk_sharing.ListRecipientsRequest{})
+			if err != nil {
+				return err
+			}
+			for offset, recipient := range recipients {
+				if !ic.MatchesName(recipient.Name) || !ic.MatchesOwner(recipient.Owner) {
+					log.Printf("[DEBUG] Recipient %s doesn't match selection filters", recipient.Name)
+					continue
+				}
+				ic.Emit(&resource{
+					Resource: "databricks_recipient",
+					ID:       recipient.Name,
+				})
+				log.Printf("[INFO] Scanned %d of %d recipients", offset+1, len(recipients))
+			}
+			return nil
+		},
+		ShouldOmitField: func(ic *importContext, pathString string, as *schema.Schema, d *schema.ResourceData) bool {
+			// sharing code is used only for the token-based authentication
+			if pathString == "sharing_code" {
+				return d.Get("authentication_type").(string) != "TOKEN"
+			}
+			return defaultShouldOmitFieldFunc(ic, pathString, as, d)
+		},
+		Depends: []reference{
+			{Path: "sharing_code", Variable: true},
+		},
+	},
+	"databricks_provider": {
+		WorkspaceLevel: true,
+		Service:        "delta-sharing",
+		Name: func(ic *importContext, d *schema.ResourceData) string {
+			return nameNormalizationRegex.ReplaceAllString(d.Id(), "_")
+		},
+		List: func(ic *importContext) error {
+			w, err := ic.Client.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			providers, err := w.Providers.ListAll(ic.Context, sdk_sharing.ListProvidersRequest{})
+			if err != nil {
+				return err
+			}
+			for offset, provider := range providers {
+				// providers for Databricks-to-Databricks sharing are created automatically
+				if provider.AuthenticationType != sdk_sharing.AuthenticationTypeToken {
+					log.Printf("[DEBUG] Skipping provider %s with %s authentication", provider.Name,
+						provider.AuthenticationType)
+					continue
+				}
+				if !ic.MatchesName(provider.Name) || !ic.MatchesOwner(provider.Owner) {
+					log.Printf("[DEBUG] Provider %s doesn't match selection filters", provider.Name)
+					continue
+				}
+				ic.Emit(&resource{
+					Resource: "databricks_provider",
+					ID:       provider.Name,
+				})
+				log.Printf("[INFO] Scanned %d of %d providers", offset+1, len(providers))
+			}
+			return nil
+		},
+		Depends: []reference{
+			{Path: "recipient_profile_str", Variable: true},
+		},
+	},
+	"databricks_mws_workspaces": {
+		AccountLevel: true,
+		Service:      "mws",
+		Name: func(ic *importContext, d *schema.ResourceData) string {
+			return d.Get("workspace_name").(string)
+		},
+		List: func(ic *importContext) error {
+			workspaces, err := mws.NewWorkspacesAPI(ic.Context, ic.Client).List(ic.Client.Config.AccountID)
+			if err != nil {
+				return err
+			}
+			for offset, ws := range workspaces {
+				if !ic.MatchesName(ws.WorkspaceName) {
+					continue
+				}
+				ic.emitMwsResource("databricks_mws_workspaces", fmt.Sprintf("%d", ws.WorkspaceID))
+				log.Printf("[INFO] Scanned %d of %d workspaces", offset+1, len(workspaces))
+			}
+			return nil
+		},
+		Import: func(ic *importContext, r *resource) error {
+			ic.emitMwsResource("databricks_mws_credentials", r.Data.Get("credentials_id").(string))
+			ic.emitMwsResource("databricks_mws_storage_configurations", r.Data.Get("storage_configuration_id").(string))
+			ic.emitMwsResource("databricks_mws_networks", r.Data.Get("network_id").(string))
+			ic.emitMwsResource("databricks_mws_private_access_settings", r.Data.Get("private_access_settings_id").(string))
+			for _, key := range []string{"customer_managed_key_id", "managed_services_customer_managed_key_id",
+				"storage_customer_managed_key_id"} {
+				ic.emitMwsResource("databricks_mws_customer_managed_keys", r.Data.Get(key).(string))
+			}
+			return nil
+		},
+		Depends: []reference{
+			{Path: "credentials_id", Resource: "databricks_mws_credentials", Match: "credentials_id"},
+			{Path: "storage_configuration_id", Resource: "databricks_mws_storage_configurations",
+				Match: "storage_configuration_id"},
+			{Path: "network_id", Resource: "databricks_mws_networks", Match: "network_id"},
+			{Path: "private_access_settings_id", Resource: "databricks_mws_private_access_settings",
+				Match: "private_access_settings_id"},
+			{Path: "customer_managed_key_id", Resource: "databricks_mws_customer_managed_keys",
+				Match: "customer_managed_key_id"},
+			{Path: "managed_services_customer_managed_key_id", Resource: "databricks_mws_customer_managed_keys",
+				Match: "customer_managed_key_id"},
+			{Path: "storage_customer_managed_key_id", Resource: "databricks_mws_customer_managed_keys",
+				Match: "customer_managed_key_id"},
+		},
+	},
+	"databricks_mws_credentials": {
+		AccountLevel: true,
+		Service:      "mws",
+		Name:         makeMwsNameFunc("credentials_name", "credentials_id"),
+		List: func(ic *importContext) error {
+			credentials, err := mws.NewCredentialsAPI(ic.Context, ic.Client).List(ic.Client.Config.AccountID)
+			if err != nil {
+				return err
+			}
+			for offset, c := range credentials {
+				if !ic.MatchesName(c.CredentialsName) {
+					continue
+				}
+				ic.emitMwsResource("databricks_mws_credentials", c.CredentialsID)
+				log.Printf("[INFO] Scanned %d of %d credentials", offset+1, len(credentials))
+			}
+			return nil
+		},
+	},
+	"databricks_mws_storage_configurations": {
+		AccountLevel: true,
+		Service:      "mws",
+		Name:         makeMwsNameFunc("storage_configuration_name", "storage_configuration_id"),
+		List: func(ic *importContext) error {
+			configurations, err := mws.NewStorageConfigurationsAPI(ic.Context, ic.Client).List(ic.Client.Config.AccountID)
+			if err != nil {
+				return err
+			}
+			for offset, sc := range configurations {
+				if !ic.MatchesName(sc.StorageConfigurationName) {
+					continue
+				}
+				ic.emitMwsResource("databricks_mws_storage_configurations", sc.StorageConfigurationID)
+				log.Printf("[INFO] Scanned %d of %d storage configurations", offset+1, len(configurations))
+			}
+			return nil
+		},
+	},
+	"databricks_mws_networks": {
+		AccountLevel: true,
+		Service:      "mws",
+		Name:         makeMwsNameFunc("network_name", "network_id"),
+		List: func(ic *importContext) error {
+			networks, err := mws.NewNetworksAPI(ic.Context, ic.Client).List(ic.Client.Config.AccountID)
+			if err != nil {
+				return err
+			}
+			for offset, n := range networks {
+				if !ic.MatchesName(n.NetworkName) {
+					continue
+				}
+				ic.emitMwsResource("databricks_mws_networks", n.NetworkID)
+				log.Printf("[INFO] Scanned %d of %d networks", offset+1, len(networks))
+			}
+			return nil
+		},
+		Import: func(ic *importContext, r *resource) error {
+			var network mws.Network
+			common.DataToStructPointer(r.Data, ic.Resources["databricks_mws_networks"].Schema, &network)
+			if network.VPCEndpoints != nil {
+				for _, id := range append(network.VPCEndpoints.RestAPI, network.VPCEndpoints.DataplaneRelayAPI...) {
+					ic.emitMwsResource("databricks_mws_vpc_endpoint", id)
+				}
+			}
+			return nil
+		},
+		ShouldOmitField: func(ic *importContext, pathString string, as *schema.Schema, d *schema.ResourceData) bool {
+			// VPC endpoints are marked as computed, but they are required for back-end PrivateLink
+			if pathString == "vpc_endpoints" {
+				return false
+			}
+			return defaultShouldOmitFieldFunc(ic, pathString, as, d)
+		},
+		Depends: []reference{
+			{Path: "vpc_endpoints.rest_api", Resource: "databricks_mws_vpc_endpoint", Match: "vpc_endpoint_id"},
+			{Path: "vpc_endpoints.dataplane_relay", Resource: "databricks_mws_vpc_endpoint", Match: "vpc_endpoint_id"},
+		},
+	},
+	"databricks_mws_vpc_endpoint": {
+		AccountLevel: true,
+		Service:      "mws",
+		Name:         makeMwsNameFunc("vpc_endpoint_name", "vpc_endpoint_id"),
+		List: func(ic *importContext) error {
+			endpoints, err := mws.NewVPCEndpointAPI(ic.Context, ic.Client).List(ic.Client.Config.AccountID)
+			if err != nil {
+				return err
+			}
+			for offset, ve := range endpoints {
+				if !ic.MatchesName(ve.VPCEndpointName) {
+					continue
+				}
+				ic.emitMwsResource("databricks_mws_vpc_endpoint", ve.VPCEndpointID)
+				log.Printf("[INFO] Scanned %d of %d VPC endpoints", offset+1, len(endpoints))
+			}
+			return nil
+		},
+	},
+	"databricks_mws_private_access_settings": {
+		AccountLevel: true,
+		Service:      "mws",
+		Name:         makeMwsNameFunc("private_access_settings_name", "private_access_settings_id"),
+		List: func(ic *importContext) error {
+			settings, err := mws.NewPrivateAccessSettingsAPI(ic.Context, ic.Client).List(ic.Client.Config.AccountID)
+			if err != nil {
+				return err
+			}
+			for offset, pas := range settings {
+				if !ic.MatchesName(pas.PasName) {
+					continue
+				}
+				ic.emitMwsResource("databricks_mws_private_access_settings", pas.PasID)
+				log.Printf("[INFO] Scanned %d of %d private access settings", offset+1, len(settings))
+			}
+			return nil
+		},
+		Import: func(ic *importContext, r *resource) error {
+			var pas mws.PrivateAccessSettings
+			common.DataToStructPointer(r.Data, ic.Resources["databricks_mws_private_access_settings"].Schema, &pas)
+			for _, id := range pas.AllowedVpcEndpointIDS {
+				ic.emitMwsResource("databricks_mws_vpc_endpoint", id)
+			}
+			return nil
+		},
+		Depends: []reference{
+			{Path: "allowed_vpc_endpoint_ids", Resource: "databricks_mws_vpc_endpoint", Match: "vpc_endpoint_id"},
+		},
+	},
+	"databricks_mws_customer_managed_keys": {
+		AccountLevel: true,
+		Service:      "mws",
+		Name: func(ic *importContext, d *schema.ResourceData) string {
+			id := d.Get("customer_managed_key_id").(string)
+			if len(id) > 8 {
+				id = id[:8]
+			}
+			return "cmk_" + id
+		},
+		List: func(ic *importContext) error {
+			keys, err := mws.NewCustomerManagedKeysAPI(ic.Context, ic.Client).List(ic.Client.Config.AccountID)
+			if err != nil {
+				return err
+			}
+			for offset, key := range keys {
+				ic.emitMwsResource("databricks_mws_customer_managed_keys", key.CustomerManagedKeyID)
+				log.Printf("[INFO] Scanned %d of %d customer-managed keys", offset+1, len(keys))
+			}
+			return nil
+		},
+	},
+	"databricks_mws_log_delivery": {
+		AccountLevel: true,
+		Service:      "mws",
+		Name:         makeMwsNameFunc("config_name", "config_id"),
+		List: func(ic *importContext) error {
+			configs, err := mws.NewLogDeliveryAPI(ic.Context, ic.Client).List(ic.Client.Config.AccountID)
+			if err != nil {
+				return err
+			}
+			for offset, ldc := range configs {
+				if !ic.MatchesName(ldc.ConfigName) {
+					continue
+				}
+				// log delivery uses different separator in its ID
+				ic.Emit(&resource{
+					Resource: "databricks_mws_log_delivery",
+					ID:       fmt.Sprintf("%s|%s", ic.Client.Config.AccountID, ldc.ConfigID),
+				})
+				log.Printf("[INFO] Scanned %d of %d log delivery configurations", offset+1, len(configs))
+			}
+			return nil
+		},
+		Import: func(ic *importContext, r *resource) error {
+			var ldc mws.LogDeliveryConfiguration
+			common.DataToStructPointer(r.Data, ic.Resources["databricks_mws_log_delivery"].Schema, &ldc)
+			ic.emitMwsResource("databricks_mws_credentials", ldc.CredentialsID)
+			ic.emitMwsResource("databricks_mws_storage_configurations", ldc.StorageConfigurationID)
+			for _, wsID := range ldc.WorkspaceIdsFilter {
+				ic.emitMwsResource("databricks_mws_workspaces", fmt.Sprintf("%d", wsID))
+			}
+			return nil
+		},
+		Depends: []reference{
+			{Path: "credentials_id", Resource: "databricks_mws_credentials", Match: "credentials_id"},
+			{Path: "storage_configuration_id", Resource: "databricks_mws_storage_configurations",
+				Match: "storage_configuration_id"},
+		},
+	},
 }
@@ -9,12 +9,18 @@ import (
 	"testing"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
+	sdk_catalog "github.com/databricks/databricks-sdk-go/service/catalog"
 	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/databricks-sdk-go/service/ml"
+	sdk_sharing "github.com/databricks/databricks-sdk-go/service/sharing"
+	"github.com/databricks/terraform-provider-databricks/catalog"
 	"github.com/databricks/terraform-provider-databricks/clusters"
 	"github.com/databricks/terraform-provider-databricks/commands"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/jobs"
 	"github.com/databricks/terraform-provider-databricks/libraries"
+	"github.com/databricks/terraform-provider-databricks/mlflow"
+	"github.com/databricks/terraform-provider-databricks/mws"
 	"github.com/databricks/terraform-provider-databricks/permissions"
 	"github.com/databricks/terraform-provider-databricks/pipelines"
 	"github.com/databricks/terraform-provider-databricks/policies"
@@ -24,6 +30,7 @@ import (
 	"github.com/databricks/terraform-provider-databricks/repos"
 	"github.com/databricks/terraform-provider-databricks/scim"
 	"github.com/databricks/terraform-provider-databricks/secrets"
+	"github.com/databricks/terraform-provider-databricks/serving"
 	"github.com/databricks/terraform-provider-databricks/storage"
 	"github.com/databricks/terraform-provider-databricks/workspace"
 	"github.com/hashicorp/hcl/v2/hclwrite"
@@ -1199,3 +1206,741 @@ func TestIncrementalListDLT(t *testing.T) {
 		assert.Equal(t, 1, len(ic.testEmits))
 	})
 }
+
+func TestImportUcCatalog(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/unity-catalog/schemas?catalog_name=abc",
+			Response: sdk_catalog.ListSchemasResponse{
+				Schemas: []sdk_catalog.SchemaInfo{
+					{
+						Name:        "information_schema",
+						CatalogName: "abc",
+						FullName:    "abc.information_schema",
+					},
+					{
+						Name:        "def",
+						CatalogName: "abc",
+						FullName:    "abc.def",
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		ic := importContextForTest()
+		ic.Client = client
+		ic.Context = ctx
+		d := catalog.ResourceCatalog().TestResourceData()
+		d.SetId("abc")
+		d.Set("name", "abc")
+		err := resourcesMap["databricks_catalog"].Import(ic, &resource{
+			ID:   "abc",
+			Data: d,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, ic.testEmits, 2)
+		assert.True(t, ic.testEmits["databricks_schema[<unknown>] (id: abc.def)"])
+		assert.True(t, ic.testEmits["databricks_grants[<unknown>] (id: catalog/abc)"])
+	})
+}
+
+func TestImportUcSchemaEmitsTablesAndVolumes(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/unity-catalog/tables?catalog_name=abc&schema_name=def",
+			Response: sdk_catalog.ListTablesResponse{
+				Tables: []sdk_catalog.TableInfo{
+					{
+						FullName:  "abc.def.t1",
+						TableType: sdk_catalog.TableTypeManaged,
+					},
+					{
+						FullName:  "abc.def.mv",
+						TableType: sdk_catalog.TableTypeMaterializedView,
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/unity-catalog/volumes?catalog_name=abc&schema_name=def",
+			Response: sdk_catalog.ListVolumesResponseContent{
+				Volumes: []sdk_catalog.VolumeInfo{
+					{
+						FullName: "abc.def.vol",
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/unity-catalog/models?catalog_name=abc&schema_name=def",
+			Response: sdk_catalog.ListRegisteredModelsResponse{
+				RegisteredModels: []sdk_catalog.RegisteredModelInfo{
+					{
+						FullName: "abc.def.model",
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		ic := importContextForTest()
+		ic.Client = client
+		ic.Context = ctx
+		d := catalog.ResourceSchema().TestResourceData()
+		d.SetId("abc.def")
+		d.Set("name", "def")
+		d.Set("catalog_name", "abc")
+		err := resourcesMap["databricks_schema"].Import(ic, &resource{
+			ID:   "abc.def",
+			Data: d,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, ic.testEmits, 5)
+		assert.True(t, ic.testEmits["databricks_catalog[<unknown>] (id: abc)"])
+		assert.True(t, ic.testEmits["databricks_grants[<unknown>] (id: schema/abc.def)"])
+		assert.True(t, ic.testEmits["databricks_sql_table[<unknown>] (id: abc.def.t1)"])
+		assert.True(t, ic.testEmits["databricks_volume[<unknown>] (id: abc.def.vol)"])
+		assert.True(t, ic.testEmits["databricks_registered_model[<unknown>] (id: abc.def.model)"])
+	})
+}
+
+func TestImportUcGrants(t *testing.T) {
+	d := catalog.ResourceGrants().TestResourceData()
+	d.SetId("catalog/abc")
+	d.Set("grant", []any{
+		map[string]any{
+			"principal":  "user@domain.com",
+			"privileges": []any{"USE_CATALOG"},
+		},
+		map[string]any{
+			"principal":  "data engineers",
+			"privileges": []any{"USE_CATALOG"},
+		},
+	})
+	ic := importContextForTest()
+	r := &resource{
+		ID:   "catalog/abc",
+		Data: d,
+	}
+	err := resourcesMap["databricks_grants"].Import(ic, r)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", r.Data.Get("catalog"))
+	assert.Len(t, ic.testEmits, 2)
+	assert.True(t, ic.testEmits["databricks_user[<unknown>] (user_name: user@domain.com)"])
+	assert.True(t, ic.testEmits["databricks_group[<unknown>] (display_name: data engineers)"])
+
+	err = resourcesMap["databricks_grants"].Import(ic, &resource{
+		ID:   "abc",
+		Data: d,
+	})
+	assert.EqualError(t, err, "incorrect grants ID: abc")
+}
+
+func TestIsMatchingCatalog(t *testing.T) {
+	d := catalog.ResourceSqlTable().TestResourceData()
+	d.Set("catalog_name", "abc")
+	ic := importContextForTest()
+	sr := func(catalogName string) resourceApproximation {
+		return resourceApproximation{
+			Instances: []instanceApproximation{
+				{Attributes: map[string]any{"catalog_name": catalogName, "name": "def"}},
+			},
+		}
+	}
+	assert.True(t, isMatchingCatalog(ic, d, sr("abc")))
+	assert.False(t, isMatchingCatalog(ic, d, sr("xyz")))
+	assert.True(t, isMatchingCatalog(ic, nil, sr("xyz")))
+}
+
+func TestUcExternalLocationGeneration(t *testing.T) {
+	testGenerate(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.1/unity-catalog/external-locations/loc?",
+			Response: sdk_catalog.ExternalLocationInfo{
+				Name:           "loc",
+				Url:            "s3://bucket/path",
+				CredentialName: "cred",
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.1/unity-catalog/storage-credentials/cred?",
+			Response: sdk_catalog.StorageCredentialInfo{
+				Name: "cred",
+				AwsIamRole: &sdk_catalog.AwsIamRole{
+					RoleArn: "arn:aws:iam::1234567890:role/uc",
+				},
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.1/unity-catalog/permissions/external_location/loc",
+			Response: catalog.PermissionsList{
+				Assignments: []catalog.PrivilegeAssignment{
+					{
+						Principal:  "data engineers",
+						Privileges: []string{"CREATE_EXTERNAL_TABLE"},
+					},
+				},
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.1/unity-catalog/permissions/storage_credential/cred",
+			Status:       404,
+			Response:     apierr.NotFound("nope"),
+		},
+	}, "uc", false, func(ic *importContext) {
+		ic.Emit(&resource{
+			Resource: "databricks_external_location",
+			ID:       "loc",
+		})
+
+		ic.waitGroup.Wait()
+		ic.closeImportChannels()
+		ic.generateHclForResources(nil)
+		assert.Equal(t, commands.TrimLeadingWhitespace(`
+		resource "databricks_storage_credential" "cred" {
+		  name = "cred"
+		  aws_iam_role {
+		    role_arn = "arn:aws:iam::1234567890:role/uc"
+		  }
+		}
+		resource "databricks_grants" "external_location_loc" {
+		  grant {
+		    privileges = ["CREATE_EXTERNAL_TABLE"]
+		    principal  = "data engineers"
+		  }
+		  external_location = databricks_external_location.loc.id
+		}
+		resource "databricks_external_location" "loc" {
+		  url             = "s3://bucket/path"
+		  name            = "loc"
+		  credential_name = databricks_storage_credential.cred.id
+		}`), string(ic.Files["uc"].Bytes()))
+	})
+}
+
+func TestImportMwsWorkspacesList(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc/workspaces",
+			Response: []mws.Workspace{
+				{
+					WorkspaceID:   123,
+					WorkspaceName: "prod",
+				},
+				{
+					WorkspaceID:   456,
+					WorkspaceName: "dev",
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		client.Config.AccountID = "abc"
+		ic := importContextForTest()
+		ic.Client = client
+		ic.Context = ctx
+		ic.match = "prod"
+		err := resourcesMap["databricks_mws_workspaces"].List(ic)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(ic.testEmits))
+		assert.True(t, ic.testEmits["databricks_mws_workspaces[<unknown>] (id: abc/123)"])
+	})
+}
+
+func TestImportMwsWorkspaceEmitsDependencies(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{}, func(ctx context.Context, client *common.DatabricksClient) {
+		client.Config.AccountID = "abc"
+		ic := importContextForTest()
+		ic.Client = client
+		ic.Context = ctx
+		d := mws.ResourceMwsWorkspaces().TestResourceData()
+		d.SetId("abc/123")
+		d.Set("workspace_name", "prod")
+		d.Set("credentials_id", "cred")
+		d.Set("storage_configuration_id", "storage")
+		d.Set("network_id", "net")
+		d.Set("managed_services_customer_managed_key_id", "cmk")
+		err := resourcesMap["databricks_mws_workspaces"].Import(ic, &resource{
+			ID:   "abc/123",
+			Data: d,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]bool{
+			"databricks_mws_credentials[<unknown>] (id: abc/cred)":               true,
+			"databricks_mws_storage_configurations[<unknown>] (id: abc/storage)": true,
+			"databricks_mws_networks[<unknown>] (id: abc/net)":                   true,
+			"databricks_mws_customer_managed_keys[<unknown>] (id: abc/cmk)":      true,
+		}, ic.testEmits)
+		assert.Equal(t, "prod", resourcesMap["databricks_mws_workspaces"].Name(ic, d))
+	})
+}
+
+func TestMwsNameFunc(t *testing.T) {
+	ic := importContextForTest()
+	d := mws.ResourceMwsCredentials().TestResourceData()
+	d.Set("credentials_id", "0123456789abcdef")
+	nameFunc := resourcesMap["databricks_mws_credentials"].Name
+	assert.Equal(t, "01234567", nameFunc(ic, d))
+	d.Set("credentials_name", "creds")
+	assert.Equal(t, "creds_01234567", nameFunc(ic, d))
+}
+
+func TestMwsNetworkGeneration(t *testing.T) {
+	testGenerate(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/accounts/abc/networks/net",
+			Response: mws.Network{
+				AccountID:   "abc",
+				NetworkID:   "net",
+				NetworkName: "network",
+				VPCID:       "vpc-1",
+				SubnetIds:   []string{"subnet-1"},
+				VPCEndpoints: &mws.NetworkVPCEndpoints{
+					RestAPI:           []string{"rest"},
+					DataplaneRelayAPI: []string{"relay"},
+				},
+				SecurityGroupIds: []string{"sg-1"},
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/accounts/abc/vpc-endpoints/rest",
+			Response: mws.VPCEndpoint{
+				AccountID:        "abc",
+				VPCEndpointID:    "rest",
+				VPCEndpointName:  "rest",
+				AwsVPCEndpointID: "vpce-1",
+				Region:           "us-east-1",
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/accounts/abc/vpc-endpoints/relay",
+			Response: mws.VPCEndpoint{
+				AccountID:        "abc",
+				VPCEndpointID:    "relay",
+				VPCEndpointName:  "relay",
+				AwsVPCEndpointID: "vpce-2",
+				Region:           "us-east-1",
+			},
+		},
+	}, "mws", true, func(ic *importContext) {
+		ic.Client.Config.AccountID = "abc"
+		ic.emitMwsResource("databricks_mws_networks", "net")
+
+		ic.waitGroup.Wait()
+		ic.closeImportChannels()
+		ic.generateHclForResources(nil)
+		assert.Equal(t, commands.TrimLeadingWhitespace(`
+		resource "databricks_mws_networks" "network_net" {
+		  vpc_id = "vpc-1"
+		  vpc_endpoints {
+		    rest_api        = [databricks_mws_vpc_endpoint.rest_rest.vpc_endpoint_id]
+		    dataplane_relay = [databricks_mws_vpc_endpoint.relay_relay.vpc_endpoint_id]
+		  }
+		  subnet_ids         = ["subnet-1"]
+		  security_group_ids = ["sg-1"]
+		  network_name       = "network"
+		  account_id         = "abc"
+		}
+		resource "databricks_mws_vpc_endpoint" "relay_relay" {
+		  vpc_endpoint_name   = "relay"
+		  region              = "us-east-1"
+		  aws_vpc_endpoint_id = "vpce-2"
+		  account_id          = "abc"
+		}
+		resource "databricks_mws_vpc_endpoint" "rest_rest" {
+		  vpc_endpoint_name   = "rest"
+		  region              = "us-east-1"
+		  aws_vpc_endpoint_id = "vpce-1"
+		  account_id          = "abc"
+		}`), string(ic.Files["mws"].Bytes()))
+	})
+}
+
+func TestMlflowExperimentsList(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/mlflow/experiments/list?",
+			Response: ml.ListExperimentsResponse{
+				Experiments: []ml.Experiment{
+					{
+						ExperimentId: "123",
+						Name:         "/Shared/team/experiment",
+					},
+					{
+						ExperimentId: "456",
+						Name:         "/Users/user@domain.com/notebook",
+						Tags: []ml.ExperimentTag{
+							{Key: "mlflow.experimentType", Value: "NOTEBOOK"},
+						},
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		ic := importContextForTest()
+		ic.Client = client
+		ic.Context = ctx
+		err := resourcesMap["databricks_mlflow_experiment"].List(ic)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]bool{"databricks_mlflow_experiment[<unknown>] (id: 123)": true}, ic.testEmits)
+	})
+}
+
+func TestMlflowExperimentImport(t *testing.T) {
+	ic := importContextForTest()
+	ic.meAdmin = true
+	d := mlflow.ResourceMlflowExperiment().TestResourceData()
+	d.SetId("123")
+	d.Set("name", "/Shared/team/experiment")
+	err := resourcesMap["databricks_mlflow_experiment"].Import(ic, &resource{
+		ID:   "123",
+		Data: d,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"databricks_directory[<unknown>] (id: /Shared/team)":                                   true,
+		"databricks_permissions[experiment_Shared_team_experiment_123] (id: /experiments/123)": true,
+	}, ic.testEmits)
+}
+
+func TestMlflowModelsListAndImport(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/mlflow/registered-models/list?",
+			Response: ml.ListModelsResponse{
+				RegisteredModels: []ml.Model{
+					{
+						Name:   "churn",
+						UserId: "alice@example.com",
+					},
+					{
+						Name:   "other",
+						UserId: "bob@example.com",
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/mlflow/registry-webhooks/list?model_name=churn",
+			Response: ml.ListRegistryWebhooks{
+				Webhooks: []ml.RegistryWebhook{
+					{
+						Id:        "abc",
+						ModelName: "churn",
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		ic := importContextForTest()
+		ic.Client = client
+		ic.Context = ctx
+		ic.meAdmin = true
+		ic.owners = map[string]bool{"alice@example.com": true}
+		err := resourcesMap["databricks_mlflow_model"].List(ic)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]bool{"databricks_mlflow_model[<unknown>] (id: churn)": true}, ic.testEmits)
+
+		d := mlflow.ResourceMlflowModel().TestResourceData()
+		d.SetId("churn")
+		d.Set("name", "churn")
+		d.Set("registered_model_id", "789")
+		err = resourcesMap["databricks_mlflow_model"].Import(ic, &resource{
+			ID:   "churn",
+			Data: d,
+		})
+		assert.NoError(t, err)
+		assert.True(t, ic.testEmits["databricks_permissions[mlflow_model_churn] (id: /registered-models/789)"])
+		assert.True(t, ic.testEmits["databricks_mlflow_webhook[<unknown>] (id: abc)"])
+	})
+}
+
+func TestMlflowModelPermissionsReference(t *testing.T) {
+	ic := importContextForTest()
+	ic.State.Append(resourceApproximation{
+		Type: "databricks_mlflow_model",
+		Name: "churn",
+		Mode: "managed",
+		Instances: []instanceApproximation{
+			{
+				Attributes: map[string]any{
+					"id":                  "churn",
+					"name":                "churn",
+					"registered_model_id": "789",
+				},
+			},
+		},
+	})
+	d := permissions.ResourcePermissions().TestResourceData()
+	d.SetId("/registered-models/789")
+	d.Set("registered_model_id", "789")
+	f := hclwrite.NewEmptyFile()
+	err := ic.dataToHcl(ic.Importables["databricks_permissions"], []string{},
+		ic.Resources["databricks_permissions"], d, f.Body())
+	assert.NoError(t, err)
+	assert.Contains(t, string(f.Bytes()),
+		"registered_model_id = databricks_mlflow_model.churn.registered_model_id")
+}
+
+func TestModelServingEmitsServedModels(t *testing.T) {
+	ic := importContextForTest()
+	d := serving.ResourceModelServing().TestResourceData()
+	d.SetId("endpoint")
+	d.Set("name", "endpoint")
+	d.Set("config", []any{
+		map[string]any{
+			"served_models": []any{
+				map[string]any{
+					"model_name":    "churn",
+					"model_version": "1",
+				},
+				map[string]any{
+					"model_name":    "main.default.forecast",
+					"model_version": "2",
+				},
+			},
+		},
+	})
+	err := resourcesMap["databricks_model_serving"].Import(ic, &resource{
+		ID:   "endpoint",
+		Data: d,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"databricks_mlflow_model[<unknown>] (id: churn)":                     true,
+		"databricks_registered_model[<unknown>] (id: main.default.forecast)": true,
+	}, ic.testEmits)
+}
+
+func TestImportUcRegisteredModel(t *testing.T) {
+	ic := importContextForTest()
+	d := catalog.ResourceRegisteredModel().TestResourceData()
+	d.SetId("main.default.forecast")
+	d.Set("name", "forecast")
+	d.Set("catalog_name", "main")
+	d.Set("schema_name", "default")
+	err := resourcesMap["databricks_registered_model"].Import(ic, &resource{
+		ID:   "main.default.forecast",
+		Data: d,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"databricks_catalog[<unknown>] (id: main)":                       true,
+		"databricks_schema[<unknown>] (id: main.default)":                true,
+		"databricks_grants[<unknown>] (id: model/main.default.forecast)": true,
+	}, ic.testEmits)
+}
+
+func TestMlflowExperimentGeneration(t *testing.T) {
+	testGenerate(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/mlflow/experiments/get?experiment_id=123",
+			Response: map[string]any{
+				"experiment": map[string]any{
+					"experiment_id": "123",
+					"name":          "/Shared/team/experiment",
+				},
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/workspace/get-status?path=%2FShared%2Fteam",
+			Response: workspace.ObjectStatus{
+				ObjectID:   456,
+				ObjectType: workspace.Directory,
+				Path:       "/Shared/team",
+			},
+		},
+	}, "mlflow-experiments,directories", false, func(ic *importContext) {
+		ic.Emit(&resource{
+			Resource: "databricks_mlflow_experiment",
+			ID:       "123",
+		})
+
+		ic.waitGroup.Wait()
+		ic.closeImportChannels()
+		ic.generateHclForResources(nil)
+		assert.Contains(t, string(ic.Files["mlflow-experiments"].Bytes()),
+			`name = "${databricks_directory.shared_team_456.path}/experiment"`)
+	})
+}
+
+func TestImportShareEmitsObjectsAndGrants(t *testing.T) {
+	d := catalog.ResourceShare().TestResourceData()
+	d.SetId("partners")
+	d.Set("name", "partners")
+	d.Set("object", []any{
+		map[string]any{
+			"name":             "main.sales.orders",
+			"data_object_type": "TABLE",
+		},
+		map[string]any{
+			"name":             "main.marketing",
+			"data_object_type": "SCHEMA",
+		},
+		map[string]any{
+			"name":             "main.sales.notebook",
+			"data_object_type": "NOTEBOOK_FILE",
+		},
+	})
+	ic := importContextForTest()
+	err := resourcesMap["databricks_share"].Import(ic, &resource{
+		ID:   "partners",
+		Data: d,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"databricks_sql_table[<unknown>] (id: main.sales.orders)": true,
+		"databricks_schema[<unknown>] (id: main.marketing)":       true,
+		"databricks_grants[<unknown>] (id: share/partners)":       true,
+	}, ic.testEmits)
+}
+
+func TestImportShareGrantsEmitRecipients(t *testing.T) {
+	d := catalog.ResourceGrants().TestResourceData()
+	d.SetId("share/partners")
+	d.Set("grant", []any{
+		map[string]any{
+			"principal":  "acme",
+			"privileges": []any{"SELECT"},
+		},
+	})
+	ic := importContextForTest()
+	err := resourcesMap["databricks_grants"].Import(ic, &resource{
+		ID:   "share/partners",
+		Data: d,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"databricks_recipient[<unknown>] (id: acme)": true,
+	}, ic.testEmits)
+}
+
+func TestDeltaSharingList(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/unity-catalog/shares",
+			Response: sdk_sharing.ListSharesResponse{
+				Shares: []sdk_sharing.ShareInfo{
+					{Name: "partners"},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/unity-catalog/recipients?",
+			Response: sdk_sharing.ListRecipientsResponse{
+				Recipients: []sdk_sharing.RecipientInfo{
+					{Name: "acme"},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/unity-catalog/providers?",
+			Response: sdk_sharing.ListProvidersResponse{
+				Providers: []sdk_sharing.ProviderInfo{
+					{
+						Name:               "vendor",
+						AuthenticationType: sdk_sharing.AuthenticationTypeToken,
+					},
+					{
+						Name:               "other-workspace",
+						AuthenticationType: sdk_sharing.AuthenticationTypeDatabricks,
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		ic := importContextForTest()
+		ic.Client = client
+		ic.Context = ctx
+		for _, resourceType := range []string{"databricks_share", "databricks_recipient", "databricks_provider"} {
+			err := resourcesMap[resourceType].List(ic)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, map[string]bool{
+			"databricks_share[<unknown>] (id: partners)":  true,
+			"databricks_recipient[<unknown>] (id: acme)":  true,
+			"databricks_provider[<unknown>] (id: vendor)": true,
+		}, ic.testEmits)
+	})
+}
+
+func TestRecipientGeneration(t *testing.T) {
+	testGenerate(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.1/unity-catalog/recipients/acme?",
+			Response: sdk_sharing.RecipientInfo{
+				Name:               "acme",
+				AuthenticationType: sdk_sharing.AuthenticationTypeToken,
+				Tokens: []sdk_sharing.RecipientTokenInfo{
+					{Id: "token1", ActivationUrl: "https://..."},
+				},
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.1/unity-catalog/recipients/other?",
+			Response: sdk_sharing.RecipientInfo{
+				Name:                           "other",
+				AuthenticationType:             sdk_sharing.AuthenticationTypeDatabricks,
+				DataRecipientGlobalMetastoreId: "aws:us-west-2:abc",
+			},
+		},
+	}, "delta-sharing", false, func(ic *importContext) {
+		ic.Emit(&resource{
+			Resource: "databricks_recipient",
+			ID:       "acme",
+		})
+		ic.Emit(&resource{
+			Resource: "databricks_recipient",
+			ID:       "other",
+		})
+
+		ic.waitGroup.Wait()
+		ic.closeImportChannels()
+		ic.generateHclForResources(nil)
+		assert.Equal(t, commands.TrimLeadingWhitespace(`
+		resource "databricks_recipient" "acme" {
+		  sharing_code        = var.sharing_code_acme
+		  name                = "acme"
+		  authentication_type = "TOKEN"
+		}
+
+		resource "databricks_recipient" "other" {
+		  name                               = "other"
+		  data_recipient_global_metastore_id = "aws:us-west-2:abc"
+		  authentication_type                = "DATABRICKS"
+		}
+		`), string(ic.Files["delta-sharing"].Bytes()))
+		assert.Contains(t, ic.variables, "sharing_code_acme")
+	})
+}
@@ -0,0 +1,154 @@
+package exporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// generate `*.tf` files together with `import.sh` script
+	outputFormatHcl = "hcl"
+	// generate `*.tf` files together with `import.tf` (Terraform 1.5+) & `manifest.json`
+	outputFormatManifest = "manifest"
+)
+
+// manifestEntry describes a single exported resource in the machine-readable form
+type manifestEntry struct {
+	// Type of the resource: `databricks_cluster`, `databricks_job`, etc.
+	Type string `json:"type"`
+	// Terraform resource name
+	Name string `json:"name"`
+	// ID that is used for import of the resource
+	ID string `json:"id"`
+	// `resource` or `data`
+	Mode    string `json:"mode"`
+	Service string `json:"service"`
+	// Path to the generated file, relative to the output directory
+	File string `json:"file"`
+	// Addresses of other resources & data sources referenced by this resource
+	DependsOn []string `json:"depends_on,omitempty"`
+	// true if resource could be imported into the Terraform state
+	Importable bool `json:"importable"`
+}
+
+// Address returns Terraform address of the resource, without the module prefix
+func (m manifestEntry) Address() string {
+	if m.Mode == "data" {
+		return fmt.Sprintf("data.%s.%s", m.Type, m.Name)
+	}
+	return fmt.Sprintf("%s.%s", m.Type, m.Name)
+}
+
+type manifest struct {
+	Resources []manifestEntry `json:"resources"`
+}
+
+func (ic *importContext) addManifestEntry(r *resource, service string, blocks []*hclwrite.Block) {
+	deps := map[string]bool{}
+	for _, block := range blocks {
+		collectDependencies(block.Body(), deps)
+	}
+	mode := "resource"
+	if r.Mode == "data" {
+		mode = "data"
+	}
+	entry := manifestEntry{
+		Type:       r.Resource,
+		Name:       r.Name,
+		ID:         r.ID,
+		Mode:       mode,
+		Service:    service,
+		File:       service + ".tf",
+		Importable: mode != "data" && ic.Resources[r.Resource].Importer != nil,
+	}
+	// resource could reference itself, i.e. in the `databricks_permissions`
+	delete(deps, entry.Address())
+	for dep := range deps {
+		entry.DependsOn = append(entry.DependsOn, dep)
+	}
+	sort.Strings(entry.DependsOn)
+	ic.manifest = append(ic.manifest, entry)
+}
+
+// collectDependencies finds all references to resources & data sources in the given body
+func collectDependencies(body *hclwrite.Body, deps map[string]bool) {
+	walkBodyAttributes(body, []string{}, func(_ string, attr *hclwrite.Attribute) {
+		for _, traversal := range attr.Expr().Variables() {
+			if address := traversalAddress(traversal); address != "" {
+				deps[address] = true
+			}
+		}
+	})
+}
+
+func (ic *importContext) generateManifest() error {
+	fileName := fmt.Sprintf("%s/manifest.json", ic.Directory)
+	entries := ic.manifest
+	if ic.incremental {
+		content, err := os.ReadFile(fileName)
+		if errors.Is(err, os.ErrNotExist) {
+			log.Printf("[WARN] File %s doesn't exist when using incremental export", fileName)
+		} else if err != nil {
+			return err
+		} else {
+			var existing manifest
+			if err = json.Unmarshal(content, &existing); err != nil {
+				return fmt.Errorf("can't parse %s: %w", fileName, err)
+			}
+			exported := map[string]bool{}
+			for _, e := range entries {
+				exported[e.Address()] = true
+			}
+			for _, e := range existing.Resources {
+				if !exported[e.Address()] {
+					entries = append(entries, e)
+				}
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Address() < entries[j].Address()
+	})
+	data, err := json.MarshalIndent(manifest{Resources: entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(fileName, data, 0644); err != nil {
+		return err
+	}
+	log.Printf("[INFO] Written manifest with %d resources", len(entries))
+	return ic.generateImportBlocks(entries)
+}
+
+// generateImportBlocks writes `import.tf` file with `import` blocks, supported since Terraform 1.5.
+// Import blocks are only allowed in the root module, so addresses never have a module prefix.
+func (ic *importContext) generateImportBlocks(entries []manifestEntry) error {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	for _, e := range entries {
+		if !e.Importable {
+			continue
+		}
+		to := hcl.Traversal{
+			hcl.TraverseRoot{Name: e.Type},
+			hcl.TraverseAttr{Name: e.Name},
+		}
+		b := body.AppendNewBlock("import", nil).Body()
+		b.SetAttributeTraversal("to", to)
+		b.SetAttributeValue("id", cty.StringVal(e.ID))
+	}
+	fileName := fmt.Sprintf("%s/import.tf", ic.Directory)
+	if err := os.WriteFile(fileName, hclwrite.Format(f.Bytes()), 0644); err != nil {
+		return err
+	}
+	log.Printf("[INFO] Created %s", fileName)
+	return nil
+}
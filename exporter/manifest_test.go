@@ -0,0 +1,167 @@
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/commands"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestCollectDependencies(t *testing.T) {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body().AppendNewBlock("resource", []string{"databricks_job", "abc"}).Body()
+	body.SetAttributeValue("name", cty.StringVal("abc"))
+	body.SetAttributeTraversal("existing_cluster_id", hcl.Traversal{
+		hcl.TraverseRoot{Name: "databricks_cluster"},
+		hcl.TraverseAttr{Name: "c1"},
+		hcl.TraverseAttr{Name: "id"},
+	})
+	task := body.AppendNewBlock("task", nil).Body()
+	task.SetAttributeTraversal("policy_id", hcl.Traversal{
+		hcl.TraverseRoot{Name: "data"},
+		hcl.TraverseAttr{Name: "databricks_cluster_policy"},
+		hcl.TraverseAttr{Name: "p1"},
+		hcl.TraverseAttr{Name: "id"},
+	})
+	task.SetAttributeTraversal("token", hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: "token"},
+	})
+
+	deps := map[string]bool{}
+	collectDependencies(body, deps)
+	assert.Equal(t, map[string]bool{
+		"databricks_cluster.c1":             true,
+		"data.databricks_cluster_policy.p1": true,
+	}, deps)
+}
+
+func TestManifestEntryAddress(t *testing.T) {
+	assert.Equal(t, "databricks_job.abc",
+		manifestEntry{Type: "databricks_job", Name: "abc", Mode: "resource"}.Address())
+	assert.Equal(t, "data.databricks_cluster_policy.abc",
+		manifestEntry{Type: "databricks_cluster_policy", Name: "abc", Mode: "data"}.Address())
+}
+
+func TestGenerateManifestIncremental(t *testing.T) {
+	ic := importContextForTest()
+	ic.Directory = fmt.Sprintf("/tmp/tf-%s", qa.RandomName())
+	require.NoError(t, os.MkdirAll(ic.Directory, 0755))
+	defer os.RemoveAll(ic.Directory)
+	ic.incremental = true
+
+	existing, _ := json.Marshal(manifest{
+		Resources: []manifestEntry{
+			{Type: "databricks_notebook", Name: "old", ID: "/old", Mode: "resource",
+				Service: "notebooks", File: "notebooks.tf", Importable: true},
+			{Type: "databricks_job", Name: "abc", ID: "111", Mode: "resource",
+				Service: "jobs", File: "jobs.tf", Importable: true},
+		},
+	})
+	require.NoError(t, os.WriteFile(ic.Directory+"/manifest.json", existing, 0644))
+
+	ic.manifest = []manifestEntry{
+		{Type: "databricks_job", Name: "abc", ID: "123", Mode: "resource",
+			Service: "jobs", File: "jobs.tf", Importable: true},
+		{Type: "databricks_cluster_policy", Name: "p1", ID: "456", Mode: "data",
+			Service: "policies", File: "policies.tf"},
+	}
+	err := ic.generateManifest()
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(ic.Directory + "/manifest.json")
+	require.NoError(t, err)
+	var m manifest
+	require.NoError(t, json.Unmarshal(content, &m))
+	require.Len(t, m.Resources, 3)
+	assert.Equal(t, "data.databricks_cluster_policy.p1", m.Resources[0].Address())
+	assert.Equal(t, "123", m.Resources[1].ID)
+	assert.Equal(t, "databricks_notebook.old", m.Resources[2].Address())
+
+	content, err = os.ReadFile(ic.Directory + "/import.tf")
+	require.NoError(t, err)
+	assert.Equal(t, commands.TrimLeadingWhitespace(`
+	import {
+	  to = databricks_job.abc
+	  id = "123"
+	}
+	import {
+	  to = databricks_notebook.old
+	  id = "/old"
+	}`), string(content))
+}
+
+func TestManifestGeneration(t *testing.T) {
+	testGenerate(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/instance-pools/get?instance_pool_id=pool1",
+			Response: compute.GetInstancePool{
+				InstancePoolId:   "pool1",
+				InstancePoolName: "pool",
+				NodeTypeId:       "m5d.large",
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/permissions/instance-pools/pool1",
+			Status:       404,
+			Response:     apierr.NotFound("nope"),
+		},
+	}, "pools", true, func(ic *importContext) {
+		ic.outputFormat = outputFormatManifest
+		ic.Emit(&resource{
+			Resource: "databricks_instance_pool",
+			ID:       "pool1",
+		})
+		ic.waitGroup.Wait()
+		ic.closeImportChannels()
+		ic.generateHclForResources(nil)
+		require.Len(t, ic.manifest, 1)
+		assert.Equal(t, manifestEntry{
+			Type:       "databricks_instance_pool",
+			Name:       "pool",
+			ID:         "pool1",
+			Mode:       "resource",
+			Service:    "pools",
+			File:       "pools.tf",
+			Importable: true,
+		}, ic.manifest[0])
+	})
+}
+
+func TestRunUnsupportedOutputFormat(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{}, func(ctx context.Context, client *common.DatabricksClient) {
+		ic := newImportContext(client)
+		ic.services = "compute"
+		ic.outputFormat = "xml"
+		err := ic.Run()
+		assert.EqualError(t, err, "unsupported output format: 'xml'")
+	})
+}
+
+func TestRunManifestWithModule(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{}, func(ctx context.Context, client *common.DatabricksClient) {
+		ic := newImportContext(client)
+		ic.services = "compute"
+		ic.outputFormat = outputFormatManifest
+		ic.Module = "module.workspace"
+		err := ic.Run()
+		assert.EqualError(t, err, "-module can't be used with -outputFormat=manifest, "+
+			"because import blocks are only allowed in the root module")
+	})
+}
@@ -129,8 +129,13 @@ type reference struct {
 	File bool
 	// regular expression (if MatchType == "regexp") must define a group that will be used to extract value to match
 	Regexp *regexp.Regexp
+	// functions to check if the found resource is a valid match, i.e. when matching attribute isn't unique
+	IsValidApproximation isValidApproximationFunc
 }
 
+// checks if the resource approximation found by reference is a valid match for the data of the referencing resource
+type isValidApproximationFunc func(ic *importContext, d *schema.ResourceData, sr resourceApproximation) bool
+
 func (r reference) MatchAttribute() string {
 	if r.Match != "" {
 		return r.Match
@@ -193,6 +198,7 @@ func (r *resource) ImportCommand(ic *importContext) string {
 
 func (r *resource) ImportResource(ic *importContext) {
 	defer ic.waitGroup.Done()
+	defer ic.removePending(r.String())
 	pr, ok := ic.Resources[r.Resource]
 	if !ok {
 		log.Printf("[ERROR] %s is not available in provider", r)
@@ -0,0 +1,168 @@
+package exporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// parameterizeRule describes which attribute of the generated resource should be replaced with variable
+type parameterizeRule struct {
+	// Resource type, like `databricks_cluster`, or `*` to match all resources
+	Resource string `json:"resource"`
+	// Path to attribute without indices, like `task.new_cluster.node_type_id`
+	Path string `json:"path"`
+	// Optional name of the variable shared by all matched values.
+	// If it's not specified, then unique variable is generated for every resource
+	Variable string `json:"variable,omitempty"`
+}
+
+func (r parameterizeRule) matches(resourceType, path string) bool {
+	return (r.Resource == "*" || r.Resource == resourceType) && r.Path == path
+}
+
+func loadParameterizeRules(fileName string) ([]parameterizeRule, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	var rules []parameterizeRule
+	if err = json.Unmarshal(content, &rules); err != nil {
+		return nil, fmt.Errorf("can't parse rules file %s: %w", fileName, err)
+	}
+	for i, rule := range rules {
+		if rule.Resource == "" || rule.Path == "" {
+			return nil, fmt.Errorf("rule %d in %s: both resource and path must be specified", i, fileName)
+		}
+	}
+	return rules, nil
+}
+
+// parameterize replaces values of matching attributes in the generated blocks with variables,
+// remembering current values for terraform.tfvars
+func (ic *importContext) parameterize(blocks []*hclwrite.Block) {
+	for _, block := range blocks {
+		labels := block.Labels()
+		if len(labels) != 2 {
+			continue
+		}
+		varPrefix := strings.TrimPrefix(labels[0], "databricks_") + "_" + labels[1]
+		ic.parameterizeBody(labels[0], block.Body(), []string{}, varPrefix)
+	}
+}
+
+func (ic *importContext) parameterizeBody(resourceType string, body *hclwrite.Body,
+	path []string, varPrefix string) {
+	attrs := body.Attributes()
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		attrPath := strings.Join(append(path, name), ".")
+		for _, rule := range ic.parameterizeRules {
+			if !rule.matches(resourceType, attrPath) {
+				continue
+			}
+			varName := rule.Variable
+			if varName == "" {
+				varName = varPrefix + "_" + name
+			}
+			ic.parameterizeAttribute(body, name, ic.regexFix(varName, simpleNameFixes),
+				fmt.Sprintf("%s of %s", attrPath, resourceType))
+			break
+		}
+	}
+	blocks := body.Blocks()
+	counts := map[string]int{}
+	for _, block := range blocks {
+		counts[block.Type()]++
+	}
+	offsets := map[string]int{}
+	for _, block := range blocks {
+		blockType := block.Type()
+		nestedPrefix := varPrefix + "_" + blockType
+		if counts[blockType] > 1 {
+			nestedPrefix += "_" + strconv.Itoa(offsets[blockType])
+		}
+		offsets[blockType]++
+		ic.parameterizeBody(resourceType, block.Body(), append(path, blockType), nestedPrefix)
+	}
+}
+
+func (ic *importContext) parameterizeAttribute(body *hclwrite.Body, name, varName, description string) {
+	expr := body.GetAttribute(name).Expr()
+	if len(expr.Variables()) > 0 {
+		// it's already a reference to another resource or variable
+		return
+	}
+	src := expr.BuildTokens(nil).Bytes()
+	parsed, diags := hclsyntax.ParseExpression(src, name, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		log.Printf("[WARN] can't parse value of %s: %s", name, diags.Error())
+		return
+	}
+	value, diags := parsed.Value(nil)
+	if diags.HasErrors() {
+		log.Printf("[WARN] can't evaluate value of %s: %s", name, diags.Error())
+		return
+	}
+	if existing, ok := ic.variableValues[varName]; ok {
+		if !existing.RawEquals(value) {
+			log.Printf("[WARN] variable %s already has a different value, keeping the first one", varName)
+		}
+	} else {
+		ic.variableValues[varName] = value
+	}
+	body.SetAttributeRaw(name, ic.variable(varName, description))
+}
+
+func (ic *importContext) generateTfvars() error {
+	if len(ic.variableValues) == 0 {
+		return nil
+	}
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	fileName := fmt.Sprintf("%s/terraform.tfvars", ic.Directory)
+	if ic.incremental {
+		content, err := os.ReadFile(fileName)
+		if errors.Is(err, os.ErrNotExist) {
+			log.Printf("[WARN] File %s doesn't exist when using incremental export", fileName)
+		} else if err != nil {
+			return err
+		} else {
+			existing, diags := hclwrite.ParseConfig(content, fileName, hcl.Pos{Line: 1, Column: 1})
+			if diags.HasErrors() {
+				return fmt.Errorf("parsing error: %s", diags.Error())
+			}
+			for name, attr := range existing.Body().Attributes() {
+				if _, present := ic.variableValues[name]; !present {
+					body.SetAttributeRaw(name, attr.Expr().BuildTokens(nil))
+				}
+			}
+		}
+	}
+	names := make([]string, 0, len(ic.variableValues))
+	for name := range ic.variableValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		body.SetAttributeValue(name, ic.variableValues[name])
+	}
+	if err := os.WriteFile(fileName, hclwrite.Format(f.Bytes()), 0644); err != nil {
+		return err
+	}
+	log.Printf("[INFO] Written %d variable values into %s", len(ic.variableValues), fileName)
+	return nil
+}
@@ -0,0 +1,170 @@
+package exporter
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/commands"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func writeTempFile(t *testing.T, content string) string {
+	fileName := fmt.Sprintf("/tmp/tf-%s.json", qa.RandomName())
+	require.NoError(t, os.WriteFile(fileName, []byte(content), 0644))
+	t.Cleanup(func() {
+		os.Remove(fileName)
+	})
+	return fileName
+}
+
+func TestLoadParameterizeRules(t *testing.T) {
+	rules, err := loadParameterizeRules(writeTempFile(t, `[
+		{"resource": "databricks_cluster", "path": "node_type_id"},
+		{"resource": "*", "path": "aws_attributes.instance_profile_arn", "variable": "instance_profile"}
+	]`))
+	require.NoError(t, err)
+	assert.Equal(t, []parameterizeRule{
+		{Resource: "databricks_cluster", Path: "node_type_id"},
+		{Resource: "*", Path: "aws_attributes.instance_profile_arn", Variable: "instance_profile"},
+	}, rules)
+	assert.True(t, rules[1].matches("databricks_job", "aws_attributes.instance_profile_arn"))
+	assert.False(t, rules[0].matches("databricks_job", "node_type_id"))
+}
+
+func TestLoadParameterizeRulesErrors(t *testing.T) {
+	_, err := loadParameterizeRules(writeTempFile(t, `{`))
+	assert.ErrorContains(t, err, "can't parse rules file")
+
+	_, err = loadParameterizeRules(writeTempFile(t, `[{"resource": "databricks_cluster"}]`))
+	assert.ErrorContains(t, err, "both resource and path must be specified")
+
+	_, err = loadParameterizeRules("/tmp/this-file-does-not-exist.json")
+	assert.Error(t, err)
+}
+
+func TestParameterizeNestedBlocks(t *testing.T) {
+	ic := importContextForTest()
+	ic.variables = map[string]string{}
+	ic.variableValues = map[string]cty.Value{}
+	ic.parameterizeRules = []parameterizeRule{
+		{Resource: "databricks_job", Path: "task.new_cluster.node_type_id"},
+		{Resource: "databricks_job", Path: "task.existing_cluster_id"},
+		{Resource: "*", Path: "email_notifications.on_failure", Variable: "alert emails"},
+	}
+	f := hclwrite.NewEmptyFile()
+	block := f.Body().AppendNewBlock("resource", []string{"databricks_job", "etl"})
+	body := block.Body()
+	for _, nodeType := range []string{"i3.xlarge", "i3.2xlarge"} {
+		task := body.AppendNewBlock("task", nil).Body()
+		task.AppendNewBlock("new_cluster", nil).Body().SetAttributeValue("node_type_id", cty.StringVal(nodeType))
+	}
+	body.AppendNewBlock("task", nil).Body().SetAttributeTraversal("existing_cluster_id", hcl.Traversal{
+		hcl.TraverseRoot{Name: "databricks_cluster"},
+		hcl.TraverseAttr{Name: "shared"},
+		hcl.TraverseAttr{Name: "id"},
+	})
+	body.AppendNewBlock("email_notifications", nil).Body().SetAttributeValue("on_failure",
+		cty.ListVal([]cty.Value{cty.StringVal("admin@example.com")}))
+
+	ic.parameterize([]*hclwrite.Block{block})
+
+	assert.Equal(t, map[string]cty.Value{
+		"job_etl_task_0_new_cluster_node_type_id": cty.StringVal("i3.xlarge"),
+		"job_etl_task_1_new_cluster_node_type_id": cty.StringVal("i3.2xlarge"),
+		"alert_emails": cty.TupleVal([]cty.Value{cty.StringVal("admin@example.com")}),
+	}, ic.variableValues)
+	assert.Equal(t, commands.TrimLeadingWhitespace(`
+	resource "databricks_job" "etl" {
+	  task {
+	    new_cluster {
+	      node_type_id = var.job_etl_task_0_new_cluster_node_type_id
+	    }
+	  }
+	  task {
+	    new_cluster {
+	      node_type_id = var.job_etl_task_1_new_cluster_node_type_id
+	    }
+	  }
+	  task {
+	    existing_cluster_id = databricks_cluster.shared.id
+	  }
+	  email_notifications {
+	    on_failure = var.alert_emails
+	  }
+	}
+	`), string(hclwrite.Format(f.Bytes())))
+}
+
+func TestParameterizeGeneration(t *testing.T) {
+	testGenerate(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/instance-pools/get?instance_pool_id=pool1",
+			Response: compute.GetInstancePool{
+				InstancePoolId:   "pool1",
+				InstancePoolName: "pool",
+				NodeTypeId:       "m5d.large",
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/permissions/instance-pools/pool1",
+			Status:       404,
+			Response:     apierr.NotFound("nope"),
+		},
+	}, "pools", true, func(ic *importContext) {
+		ic.variableValues = map[string]cty.Value{}
+		ic.parameterizeRules = []parameterizeRule{
+			{Resource: "databricks_instance_pool", Path: "node_type_id"},
+		}
+		ic.Emit(&resource{
+			Resource: "databricks_instance_pool",
+			ID:       "pool1",
+		})
+		ic.waitGroup.Wait()
+		ic.closeImportChannels()
+		ic.generateHclForResources(nil)
+		assert.Contains(t, string(ic.Files["pools"].Bytes()),
+			"node_type_id       = var.instance_pool_pool_node_type_id")
+		assert.Equal(t, "node_type_id of databricks_instance_pool",
+			ic.variables["instance_pool_pool_node_type_id"])
+
+		require.NoError(t, os.MkdirAll(ic.Directory, 0755))
+		require.NoError(t, ic.generateTfvars())
+		content, err := os.ReadFile(ic.Directory + "/terraform.tfvars")
+		require.NoError(t, err)
+		assert.Equal(t, "instance_pool_pool_node_type_id = \"m5d.large\"\n", string(content))
+	})
+}
+
+func TestGenerateTfvarsIncremental(t *testing.T) {
+	ic := importContextForTest()
+	ic.Directory = fmt.Sprintf("/tmp/tf-%s", qa.RandomName())
+	require.NoError(t, os.MkdirAll(ic.Directory, 0755))
+	defer os.RemoveAll(ic.Directory)
+	ic.incremental = true
+	require.NoError(t, os.WriteFile(ic.Directory+"/terraform.tfvars",
+		[]byte("old_value = \"abc\"\nnode_type = \"i3.xlarge\"\n"), 0644))
+	ic.variableValues = map[string]cty.Value{
+		"node_type": cty.StringVal("m5d.large"),
+		"workers":   cty.NumberIntVal(2),
+	}
+	require.NoError(t, ic.generateTfvars())
+	content, err := os.ReadFile(ic.Directory + "/terraform.tfvars")
+	require.NoError(t, err)
+	assert.Equal(t, commands.TrimLeadingWhitespace(`
+	old_value = "abc"
+	node_type = "m5d.large"
+	workers   = 2
+	`), string(content))
+}
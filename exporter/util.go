@@ -113,6 +113,21 @@ func (ic *importContext) emitUserOrServicePrincipal(userOrSPName string) {
 	}
 }
 
+func (ic *importContext) emitUserSpOrGroup(principal string) {
+	if principal == "" {
+		return
+	}
+	if strings.Contains(principal, "@") || common.StringIsUUID(principal) {
+		ic.emitUserOrServicePrincipal(principal)
+	} else {
+		ic.Emit(&resource{
+			Resource:  "databricks_group",
+			Attribute: "display_name",
+			Value:     principal,
+		})
+	}
+}
+
 func (ic *importContext) emitUserOrServicePrincipalForPath(path, prefix string) {
 	if strings.HasPrefix(path, prefix) {
 		parts := strings.SplitN(path, "/", 4)
@@ -547,8 +562,8 @@ func eitherString(a any, b any) string {
 func (ic *importContext) importJobs(l []jobs.Job) {
 	i := 0
 	for offset, job := range l {
-		if !ic.MatchesName(job.Settings.Name) {
-			log.Printf("[INFO] Job name %s doesn't match selection %s", job.Settings.Name, ic.match)
+		if !ic.MatchesObject(job.Settings.Name, job.CreatorUserName, job.Settings.Tags) {
+			log.Printf("[INFO] Job %s doesn't match selection filters", job.Settings.Name)
 			continue
 		}
 		ic.Emit(&resource{
@@ -704,6 +719,49 @@ func createListWorkspaceObjectsFunc(objType string, resourceType string, objName
 	}
 }
 
+func (ic *importContext) emitUcGrants(securable, name string) {
+	ic.Emit(&resource{
+		Resource: "databricks_grants",
+		ID:       fmt.Sprintf("%s/%s", securable, name),
+	})
+}
+
+// emitServedModel emits model served by the serving endpoint. Models from Unity Catalog are referenced
+// by three-level names, while models from workspace model registry have no such structure
+func (ic *importContext) emitServedModel(modelName string) {
+	if modelName == "" {
+		return
+	}
+	if len(strings.Split(modelName, ".")) == 3 {
+		ic.Emit(&resource{
+			Resource: "databricks_registered_model",
+			ID:       modelName,
+		})
+	} else {
+		ic.Emit(&resource{
+			Resource: "databricks_mlflow_model",
+			ID:       modelName,
+		})
+	}
+}
+
+// schema names are unique only inside the catalog, so we need to check that referenced schema is in the same catalog
+func isMatchingCatalog(ic *importContext, d *schema.ResourceData, sr resourceApproximation) bool {
+	if d == nil {
+		return true
+	}
+	catalogName, ok := d.GetOk("catalog_name")
+	if !ok {
+		return true
+	}
+	for _, i := range sr.Instances {
+		if v, ok := i.Attributes["catalog_name"].(string); ok && v == catalogName.(string) {
+			return true
+		}
+	}
+	return false
+}
+
 func (ic *importContext) getLastActiveMs() int64 {
 	if ic.lastActiveMs == 0 {
 		ic.lastActiveMs = (time.Now().Unix() - ic.lastActiveDays*24*60*60) * 1000
@@ -729,3 +787,29 @@ func getEnvAsInt(envName string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// emitMwsResource emits account-level resource that has ID in form of `<account_id>/<object_id>`
+func (ic *importContext) emitMwsResource(resourceType, objectID string) {
+	if objectID == "" {
+		return
+	}
+	ic.Emit(&resource{
+		Resource: resourceType,
+		ID:       fmt.Sprintf("%s/%s", ic.Client.Config.AccountID, objectID),
+	})
+}
+
+// mws object names aren't required to be unique, so we're adding the prefix of object ID to them
+func makeMwsNameFunc(nameAttr, idAttr string) func(ic *importContext, d *schema.ResourceData) string {
+	return func(ic *importContext, d *schema.ResourceData) string {
+		name := d.Get(nameAttr).(string)
+		objectID := d.Get(idAttr).(string)
+		if len(objectID) > 8 {
+			objectID = objectID[:8]
+		}
+		if name == "" {
+			return objectID
+		}
+		return name + "_" + objectID
+	}
+}
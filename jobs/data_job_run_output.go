@@ -0,0 +1,99 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type sqlOutput struct {
+	OutputLink  string `json:"output_link,omitempty" tf:"computed"`
+	QueryText   string `json:"query_text,omitempty" tf:"computed"`
+	WarehouseID string `json:"warehouse_id,omitempty" tf:"computed"`
+}
+
+func newSqlOutput(out *jobs.SqlOutput) []sqlOutput {
+	if out == nil {
+		return nil
+	}
+	switch {
+	case out.QueryOutput != nil:
+		return []sqlOutput{{
+			OutputLink:  out.QueryOutput.OutputLink,
+			QueryText:   out.QueryOutput.QueryText,
+			WarehouseID: out.QueryOutput.WarehouseId,
+		}}
+	case out.AlertOutput != nil:
+		return []sqlOutput{{
+			OutputLink:  out.AlertOutput.OutputLink,
+			QueryText:   out.AlertOutput.QueryText,
+			WarehouseID: out.AlertOutput.WarehouseId,
+		}}
+	case out.DashboardOutput != nil:
+		return []sqlOutput{{
+			WarehouseID: out.DashboardOutput.WarehouseId,
+		}}
+	}
+	return nil
+}
+
+// DataSourceJobRunOutput returns output of a single task run. Output of a task
+// of a multi-task run could be found by the run ID and the key of the task.
+func DataSourceJobRunOutput() *schema.Resource {
+	type jobRunOutputData struct {
+		RunID                   int64       `json:"run_id"`
+		TaskKey                 string      `json:"task_key,omitempty"`
+		TaskRunID               int64       `json:"task_run_id,omitempty" tf:"computed"`
+		LifeCycleState          string      `json:"life_cycle_state,omitempty" tf:"computed"`
+		ResultState             string      `json:"result_state,omitempty" tf:"computed"`
+		NotebookOutputResult    string      `json:"notebook_output_result,omitempty" tf:"computed"`
+		NotebookOutputTruncated bool        `json:"notebook_output_truncated,omitempty" tf:"computed"`
+		Logs                    string      `json:"logs,omitempty" tf:"computed"`
+		LogsTruncated           bool        `json:"logs_truncated,omitempty" tf:"computed"`
+		Error                   string      `json:"error,omitempty" tf:"computed"`
+		ErrorTrace              string      `json:"error_trace,omitempty" tf:"computed"`
+		SqlOutput               []sqlOutput `json:"sql_output,omitempty" tf:"computed"`
+	}
+	return common.DataResource(jobRunOutputData{}, func(ctx context.Context, e any, c *common.DatabricksClient) error {
+		data := e.(*jobRunOutputData)
+		ctx = context.WithValue(ctx, common.Api, common.API_2_1)
+		jobsAPI := NewJobsAPI(ctx, c)
+		data.TaskRunID = data.RunID
+		if data.TaskKey != "" {
+			run, err := jobsAPI.RunsGet(data.RunID)
+			if err != nil {
+				return err
+			}
+			data.TaskRunID = 0
+			for _, task := range run.Tasks {
+				if task.TaskKey == data.TaskKey {
+					data.TaskRunID = task.RunID
+				}
+			}
+			if data.TaskRunID == 0 {
+				return fmt.Errorf("run %d has no task %s", data.RunID, data.TaskKey)
+			}
+		}
+		output, err := jobsAPI.RunsGetOutput(data.TaskRunID)
+		if err != nil {
+			return err
+		}
+		if output.Metadata != nil && output.Metadata.State != nil {
+			data.LifeCycleState = string(output.Metadata.State.LifeCycleState)
+			data.ResultState = string(output.Metadata.State.ResultState)
+		}
+		if output.NotebookOutput != nil {
+			data.NotebookOutputResult = output.NotebookOutput.Result
+			data.NotebookOutputTruncated = output.NotebookOutput.Truncated
+		}
+		data.Logs = output.Logs
+		data.LogsTruncated = output.LogsTruncated
+		data.Error = output.Error
+		data.ErrorTrace = output.ErrorTrace
+		data.SqlOutput = newSqlOutput(output.SqlOutput)
+		return nil
+	})
+}
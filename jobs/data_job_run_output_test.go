@@ -0,0 +1,114 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/databricks/terraform-provider-databricks/qa"
+)
+
+func TestJobRunOutputData(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/get-output?run_id=124",
+				Response: jobs.RunOutput{
+					Metadata: &jobs.Run{
+						State: &jobs.RunState{
+							LifeCycleState: jobs.RunLifeCycleStateTerminated,
+							ResultState:    jobs.RunResultStateSuccess,
+						},
+					},
+					NotebookOutput: &jobs.NotebookOutput{
+						Result: "main.default.events",
+					},
+					Logs:          "done",
+					LogsTruncated: true,
+				},
+			},
+		},
+		Resource:    DataSourceJobRunOutput(),
+		HCL:         `run_id = 124`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ApplyAndExpectData(t, map[string]any{
+		"task_run_id":            124,
+		"life_cycle_state":       "TERMINATED",
+		"result_state":           "SUCCESS",
+		"notebook_output_result": "main.default.events",
+		"logs":                   "done",
+		"logs_truncated":         true,
+	})
+}
+
+func TestJobRunOutputData_TaskKey(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/get?run_id=123",
+				Response: JobRun{
+					RunID: 123,
+					Tasks: []JobRunTask{
+						{TaskKey: "setup", RunID: 124},
+						{TaskKey: "report", RunID: 125},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/get-output?run_id=125",
+				Response: jobs.RunOutput{
+					SqlOutput: &jobs.SqlOutput{
+						QueryOutput: &jobs.SqlQueryOutput{
+							OutputLink:  "https://example.com/sql/queries/1",
+							QueryText:   "SELECT 1",
+							WarehouseId: "abc",
+						},
+					},
+				},
+			},
+		},
+		Resource: DataSourceJobRunOutput(),
+		HCL: `
+		run_id = 123
+		task_key = "report"
+		`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ApplyAndExpectData(t, map[string]any{
+		"task_run_id":               125,
+		"sql_output.#":              1,
+		"sql_output.0.output_link":  "https://example.com/sql/queries/1",
+		"sql_output.0.query_text":   "SELECT 1",
+		"sql_output.0.warehouse_id": "abc",
+	})
+}
+
+func TestJobRunOutputData_UnknownTask(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/get?run_id=123",
+				Response: JobRun{
+					RunID: 123,
+					Tasks: []JobRunTask{
+						{TaskKey: "setup", RunID: 124},
+					},
+				},
+			},
+		},
+		Resource: DataSourceJobRunOutput(),
+		HCL: `
+		run_id = 123
+		task_key = "report"
+		`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "run 123 has no task report")
+}
@@ -0,0 +1,103 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type jobRunInfo struct {
+	RunID          int64  `json:"run_id,omitempty" tf:"computed"`
+	JobID          int64  `json:"job_id,omitempty" tf:"computed"`
+	RunName        string `json:"run_name,omitempty" tf:"computed"`
+	NumberInJob    int64  `json:"number_in_job,omitempty" tf:"computed"`
+	StartTime      int64  `json:"start_time,omitempty" tf:"computed"`
+	EndTime        int64  `json:"end_time,omitempty" tf:"computed"`
+	LifeCycleState string `json:"life_cycle_state,omitempty" tf:"computed"`
+	ResultState    string `json:"result_state,omitempty" tf:"computed"`
+	StateMessage   string `json:"state_message,omitempty" tf:"computed"`
+	Trigger        string `json:"trigger,omitempty" tf:"computed"`
+	RunPageURL     string `json:"run_page_url,omitempty" tf:"computed"`
+}
+
+// runsPageSize is the maximal number of runs returned by a single call of `runs/list`
+const runsPageSize = 25
+
+// DataSourceJobRuns lists runs of jobs, starting with the most recent. Result state isn't supported by
+// `runs/list`, so runs are filtered by it on the client side, and the number of scanned runs is limited.
+func DataSourceJobRuns() *schema.Resource {
+	type jobRunsData struct {
+		JobID         int64        `json:"job_id,omitempty"`
+		ActiveOnly    bool         `json:"active_only,omitempty"`
+		CompletedOnly bool         `json:"completed_only,omitempty"`
+		ResultState   string       `json:"result_state,omitempty"`
+		StartTimeFrom int64        `json:"start_time_from,omitempty"`
+		StartTimeTo   int64        `json:"start_time_to,omitempty"`
+		Limit         int          `json:"limit,omitempty" tf:"default:25"`
+		MaxScanned    int          `json:"max_scanned_runs,omitempty" tf:"default:1000"`
+		Runs          []jobRunInfo `json:"runs,omitempty" tf:"computed"`
+	}
+	return common.DataResource(jobRunsData{}, func(ctx context.Context, e any, c *common.DatabricksClient) error {
+		data := e.(*jobRunsData)
+		if data.ActiveOnly && data.CompletedOnly {
+			return fmt.Errorf("only one of `active_only` or `completed_only` could be specified")
+		}
+		if data.Limit < 1 {
+			return fmt.Errorf("`limit` must be positive")
+		}
+		if data.MaxScanned < 1 {
+			return fmt.Errorf("`max_scanned_runs` must be positive")
+		}
+		ctx = context.WithValue(ctx, common.Api, common.API_2_1)
+		jobsAPI := NewJobsAPI(ctx, c)
+		data.Runs = []jobRunInfo{}
+		request := JobRunsListRequest{
+			JobID:         data.JobID,
+			ActiveOnly:    data.ActiveOnly,
+			CompletedOnly: data.CompletedOnly,
+			StartTimeFrom: data.StartTimeFrom,
+			StartTimeTo:   data.StartTimeTo,
+			Limit:         runsPageSize,
+		}
+		for {
+			page, err := jobsAPI.RunsList(request)
+			if err != nil {
+				return err
+			}
+			for _, run := range page.Runs {
+				if data.ResultState != "" && run.State.ResultState != data.ResultState {
+					continue
+				}
+				data.Runs = append(data.Runs, jobRunInfo{
+					RunID:          run.RunID,
+					JobID:          run.JobID,
+					RunName:        run.RunName,
+					NumberInJob:    run.NumberInJob,
+					StartTime:      run.StartTime,
+					EndTime:        run.EndTime,
+					LifeCycleState: run.State.LifeCycleState,
+					ResultState:    run.State.ResultState,
+					StateMessage:   run.State.StateMessage,
+					Trigger:        run.Trigger,
+					RunPageURL:     run.RunPageURL,
+				})
+				if len(data.Runs) == data.Limit {
+					return nil
+				}
+			}
+			if !page.HasMore {
+				return nil
+			}
+			request.Offset += int32(len(page.Runs))
+			if int(request.Offset) >= data.MaxScanned {
+				log.Printf("[WARN] Found %d of %d runs after scanning %d runs, narrow down the search "+
+					"with job_id, start_time_from or start_time_to, or increase max_scanned_runs",
+					len(data.Runs), data.Limit, request.Offset)
+				return nil
+			}
+		}
+	})
+}
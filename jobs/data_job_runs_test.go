@@ -0,0 +1,171 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/qa"
+)
+
+func TestJobRunsData(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/list?completed_only=true&job_id=123&limit=25&start_time_from=1690000000000",
+				Response: JobRunsList{
+					Runs: []JobRun{
+						{
+							JobID: 123,
+							RunID: 3,
+							State: RunState{
+								LifeCycleState: "TERMINATED",
+								ResultState:    "FAILED",
+							},
+						},
+						{
+							JobID:      123,
+							RunID:      2,
+							StartTime:  1690000001000,
+							EndTime:    1690000002000,
+							RunPageURL: "https://example.com/#job/123/run/2",
+							State: RunState{
+								LifeCycleState: "TERMINATED",
+								ResultState:    "SUCCESS",
+							},
+						},
+					},
+					HasMore: true,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/list?completed_only=true&job_id=123&limit=25&offset=2&start_time_from=1690000000000",
+				Response: JobRunsList{
+					Runs: []JobRun{
+						{
+							JobID: 123,
+							RunID: 1,
+							State: RunState{
+								LifeCycleState: "TERMINATED",
+								ResultState:    "SUCCESS",
+							},
+						},
+					},
+				},
+			},
+		},
+		Resource: DataSourceJobRuns(),
+		HCL: `
+		job_id = 123
+		completed_only = true
+		result_state = "SUCCESS"
+		start_time_from = 1690000000000
+		`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ApplyAndExpectData(t, map[string]any{
+		"runs.#":              2,
+		"runs.0.run_id":       2,
+		"runs.0.start_time":   1690000001000,
+		"runs.0.end_time":     1690000002000,
+		"runs.0.result_state": "SUCCESS",
+		"runs.0.run_page_url": "https://example.com/#job/123/run/2",
+		"runs.1.run_id":       1,
+	})
+}
+
+func TestJobRunsData_Limit(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/list?active_only=true&limit=25",
+				Response: JobRunsList{
+					Runs: []JobRun{
+						{RunID: 3},
+						{RunID: 2},
+						{RunID: 1},
+					},
+					HasMore: true,
+				},
+			},
+		},
+		Resource: DataSourceJobRuns(),
+		HCL: `
+		active_only = true
+		limit = 2
+		`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ApplyAndExpectData(t, map[string]any{
+		"runs.#":        2,
+		"runs.0.run_id": 3,
+		"runs.1.run_id": 2,
+	})
+}
+
+func TestJobRunsData_MaxScannedRuns(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/list?limit=25",
+				Response: JobRunsList{
+					Runs: []JobRun{
+						{RunID: 3, State: RunState{ResultState: "SUCCESS"}},
+						{RunID: 2, State: RunState{ResultState: "SUCCESS"}},
+						{RunID: 1, State: RunState{ResultState: "CANCELED"}},
+					},
+					HasMore: true,
+				},
+			},
+		},
+		Resource: DataSourceJobRuns(),
+		HCL: `
+		result_state = "FAILED"
+		max_scanned_runs = 3
+		`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ApplyAndExpectData(t, map[string]any{
+		"runs.#": 0,
+	})
+}
+
+func TestJobRunsData_InvalidMaxScannedRuns(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: DataSourceJobRuns(),
+		HCL: `
+		max_scanned_runs = 0
+		`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "`max_scanned_runs` must be positive")
+}
+
+func TestJobRunsData_Conflict(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: DataSourceJobRuns(),
+		HCL: `
+		active_only = true
+		completed_only = true
+		`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "only one of `active_only` or `completed_only` could be specified")
+}
+
+func TestJobRunsData_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures:    qa.HTTPFailures,
+		Resource:    DataSourceJobRuns(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "I'm a teapot")
+}
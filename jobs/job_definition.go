@@ -0,0 +1,183 @@
+package jobs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// parseJobDefinition decodes `job_definition_json`, that is either job settings, as shown by "View JSON"
+// in the UI, or a job with settings, as returned by the Jobs API. Unknown fields are rejected, because
+// they would be silently ignored by the provider.
+func parseJobDefinition(definition string) (js JobSettings, err error) {
+	var raw map[string]json.RawMessage
+	if err = json.Unmarshal([]byte(definition), &raw); err != nil {
+		return js, fmt.Errorf("invalid job definition: %w", err)
+	}
+	settings := []byte(definition)
+	if s, ok := raw["settings"]; ok {
+		settings = s
+	}
+	decoder := json.NewDecoder(bytes.NewReader(settings))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&js); err != nil {
+		return js, fmt.Errorf("invalid job definition: %w", err)
+	}
+	js.sortTasksByKey()
+	js.sortWebhooksByID()
+	return js, nil
+}
+
+// canonicalJobDefinition returns generic representation of job settings without empty fields
+func canonicalJobDefinition(js JobSettings) (map[string]any, error) {
+	raw, err := json.Marshal(js)
+	if err != nil {
+		return nil, err
+	}
+	var canonical map[string]any
+	err = json.Unmarshal(raw, &canonical)
+	return canonical, err
+}
+
+func parseCanonicalJobDefinition(definition string) (map[string]any, error) {
+	js, err := parseJobDefinition(definition)
+	if err != nil {
+		return nil, err
+	}
+	return canonicalJobDefinition(js)
+}
+
+func validateJobDefinition(v any, _ string) (warnings []string, errs []error) {
+	if _, err := parseJobDefinition(v.(string)); err != nil {
+		errs = append(errs, err)
+	}
+	return
+}
+
+// suppressJobDefinitionDiff ignores differences in formatting, order of keys and fields with default values
+func suppressJobDefinitionDiff(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return false
+	}
+	o, err := parseCanonicalJobDefinition(old)
+	if err != nil {
+		return false
+	}
+	n, err := parseCanonicalJobDefinition(new)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(o, n)
+}
+
+// pruneToShape leaves only the fields of actual value, that are present in the configured one,
+// so that the fields filled in by the backend don't cause a diff. Elements of lists are compared
+// one by one, and extra elements are kept, so that added or removed tasks are detected.
+func pruneToShape(actual, configured any) any {
+	switch c := configured.(type) {
+	case map[string]any:
+		a, ok := actual.(map[string]any)
+		if !ok {
+			return actual
+		}
+		pruned := map[string]any{}
+		for k, v := range c {
+			if av, ok := a[k]; ok {
+				pruned[k] = pruneToShape(av, v)
+			}
+		}
+		return pruned
+	case []any:
+		a, ok := actual.([]any)
+		if !ok {
+			return actual
+		}
+		pruned := []any{}
+		for i, av := range a {
+			if i < len(c) {
+				av = pruneToShape(av, c[i])
+			}
+			pruned = append(pruned, av)
+		}
+		return pruned
+	default:
+		return actual
+	}
+}
+
+// readJobDefinition sets `job_definition_json` from the actual job settings, limited to the fields
+// of the definition in the state, so that changes are detected field by field. Tasks are already
+// sorted by their keys in both of them.
+func readJobDefinition(d *schema.ResourceData, settings JobSettings) error {
+	configured, err := parseCanonicalJobDefinition(d.Get("job_definition_json").(string))
+	if err != nil {
+		return err
+	}
+	actual, err := canonicalJobDefinition(settings)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(pruneToShape(actual, configured))
+	if err != nil {
+		return err
+	}
+	return d.Set("job_definition_json", string(raw))
+}
+
+// dataToJobSettings reads job settings either from `job_definition_json` or from the other attributes
+func dataToJobSettings(d *schema.ResourceData) (js JobSettings, err error) {
+	if definition := d.Get("job_definition_json").(string); definition != "" {
+		return parseJobDefinition(definition)
+	}
+	common.DataToStructPointer(d, jobSchema, &js)
+	return js, nil
+}
+
+// diffToJobSettings is the same as dataToJobSettings, but for the planned changes
+func diffToJobSettings(d *schema.ResourceDiff) (js JobSettings, err error) {
+	if definition := d.Get("job_definition_json").(string); definition != "" {
+		return parseJobDefinition(definition)
+	}
+	common.DiffToStructPointer(d, jobSchema, &js)
+	return js, nil
+}
+
+// jobDefinitionField returns the name of JSON field of job settings for the top-level argument,
+// e.g. `tasks` for `task`
+func jobDefinitionField(key string) string {
+	t := reflect.TypeOf(JobSettings{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		for _, tag := range strings.Split(field.Tag.Get("tf"), ",") {
+			if tag == "alias:"+key {
+				return jsonName
+			}
+		}
+		if jsonName == key {
+			return jsonName
+		}
+	}
+	return key
+}
+
+// jobDefinitionChanged tells if the top-level argument has changed within `job_definition_json`.
+// Definitions that can't be parsed, e.g. not yet known during the plan, are treated as changed.
+func jobDefinitionChanged(d changeDetector, key string) bool {
+	oldDefinition, newDefinition := d.GetChange("job_definition_json")
+	o, err := parseCanonicalJobDefinition(oldDefinition.(string))
+	if err != nil {
+		return true
+	}
+	n, err := parseCanonicalJobDefinition(newDefinition.(string))
+	if err != nil {
+		return true
+	}
+	field := jobDefinitionField(key)
+	return !reflect.DeepEqual(o[field], n[field])
+}
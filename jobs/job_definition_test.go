@@ -0,0 +1,328 @@
+package jobs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJobDefinition(t *testing.T) {
+	js, err := parseJobDefinition(`{
+		"job_id": 789,
+		"settings": {
+			"name": "Featurizer",
+			"tasks": [
+				{"task_key": "b", "notebook_task": {"notebook_path": "/b"}},
+				{"task_key": "a", "notebook_task": {"notebook_path": "/a"}}
+			]
+		}
+	}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "Featurizer", js.Name)
+	assert.Equal(t, "a", js.Tasks[0].TaskKey)
+	assert.Equal(t, "b", js.Tasks[1].TaskKey)
+}
+
+func TestParseJobDefinition_Errors(t *testing.T) {
+	_, err := parseJobDefinition(`{"name": `)
+	assert.EqualError(t, err, "invalid job definition: unexpected end of JSON input")
+
+	_, err = parseJobDefinition(`{"name": "x", "unknown_field": true}`)
+	assert.EqualError(t, err, `invalid job definition: json: unknown field "unknown_field"`)
+}
+
+func TestSuppressJobDefinitionDiff(t *testing.T) {
+	old := `{"max_concurrent_runs":1,"name":"Featurizer","tasks":[{"task_key":"a"},{"task_key":"b"}]}`
+	for _, new := range []string{
+		`{"name": "Featurizer", "max_concurrent_runs": 1, "tasks": [{"task_key": "b"}, {"task_key": "a"}]}`,
+		`{"settings": {"name": "Featurizer", "max_concurrent_runs": 1,
+			"tasks": [{"task_key": "a", "timeout_seconds": 0}, {"task_key": "b"}]}}`,
+	} {
+		assert.True(t, suppressJobDefinitionDiff("job_definition_json", old, new, nil), new)
+	}
+	for _, new := range []string{
+		"",
+		`{"name": "Featurizer", "max_concurrent_runs": 2, "tasks": [{"task_key": "a"}, {"task_key": "b"}]}`,
+		`{"name": "Featurizer", "max_concurrent_runs": 1, "tasks": [{"task_key": "a"}]}`,
+		`{"name": `,
+	} {
+		assert.False(t, suppressJobDefinitionDiff("job_definition_json", old, new, nil), new)
+	}
+}
+
+func TestPruneToShape(t *testing.T) {
+	actual := map[string]any{
+		"name":                "a",
+		"max_concurrent_runs": 1.0,
+		"tasks": []any{
+			map[string]any{"task_key": "a", "run_if": "ALL_SUCCESS"},
+			map[string]any{"task_key": "b", "run_if": "ALL_SUCCESS"},
+		},
+	}
+	configured := map[string]any{
+		"name":  "b",
+		"tags":  map[string]any{"x": "y"},
+		"tasks": []any{map[string]any{"task_key": "a"}},
+	}
+	assert.Equal(t, map[string]any{
+		"name": "a",
+		"tasks": []any{
+			map[string]any{"task_key": "a"},
+			map[string]any{"task_key": "b", "run_if": "ALL_SUCCESS"},
+		},
+	}, pruneToShape(actual, configured))
+}
+
+func TestResourceJobCreate_JobDefinitionJSON(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name:              "Featurizer",
+					MaxConcurrentRuns: 2,
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey:           "a",
+							ExistingClusterID: "abc",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/a",
+							},
+						},
+						{
+							TaskKey:           "b",
+							ExistingClusterID: "abc",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/b",
+							},
+						},
+					},
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name:              "Featurizer",
+						MaxConcurrentRuns: 2,
+						Format:            "MULTI_TASK",
+						EmailNotifications: &EmailNotifications{
+							NoAlertForSkippedRuns: true,
+						},
+						Tasks: []JobTaskSettings{
+							{
+								TaskKey:           "b",
+								ExistingClusterID: "abc",
+								RunIf:             "ALL_SUCCESS",
+								NotebookTask: &NotebookTask{
+									NotebookPath: "/b",
+									Source:       "WORKSPACE",
+								},
+							},
+							{
+								TaskKey:           "a",
+								ExistingClusterID: "abc",
+								RunIf:             "ALL_SUCCESS",
+								NotebookTask: &NotebookTask{
+									NotebookPath: "/a",
+									Source:       "WORKSPACE",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		job_definition_json = <<EOT
+		{
+			"name": "Featurizer",
+			"max_concurrent_runs": 2,
+			"tasks": [
+				{"task_key": "b", "existing_cluster_id": "abc", "notebook_task": {"notebook_path": "/b"}},
+				{"task_key": "a", "existing_cluster_id": "abc", "notebook_task": {"notebook_path": "/a"}}
+			]
+		}
+EOT
+`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id": "789",
+		"job_definition_json": `{"max_concurrent_runs":2,"name":"Featurizer","tasks":[` +
+			`{"existing_cluster_id":"abc","notebook_task":{"notebook_path":"/a"},"task_key":"a"},` +
+			`{"existing_cluster_id":"abc","notebook_task":{"notebook_path":"/b"},"task_key":"b"}]}`,
+	})
+}
+
+func TestResourceJobRead_JobDefinitionJSONDrift(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name:              "Featurizer",
+						MaxConcurrentRuns: 1,
+						Tasks: []JobTaskSettings{
+							{
+								TaskKey:           "a",
+								ExistingClusterID: "def",
+								NotebookTask: &NotebookTask{
+									NotebookPath: "/a",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Read:     true,
+		Resource: ResourceJob(),
+		ID:       "789",
+		InstanceState: map[string]string{
+			"job_definition_json": `{"name":"Featurizer","tasks":[{"task_key":"a","existing_cluster_id":"abc"}]}`,
+		},
+		HCL: `
+		job_definition_json = <<EOT
+		{"name": "Featurizer", "tasks": [{"task_key": "a", "existing_cluster_id": "abc"}]}
+EOT
+`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"job_definition_json": `{"name":"Featurizer","tasks":[{"existing_cluster_id":"def","task_key":"a"}]}`,
+	})
+}
+
+func TestResourceJobCreate_JobDefinitionJSONConflict(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Featurizer"
+		job_definition_json = "{\"name\": \"Featurizer\"}"
+		`,
+	}.ExpectError(t, "invalid config supplied. [job_definition_json] Conflicting configuration arguments")
+}
+
+func TestResourceJobCreate_JobDefinitionJSONInvalidTasks(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		job_definition_json = <<EOT
+		{
+			"tasks": [
+				{"task_key": "a", "depends_on": [{"task_key": "b"}]},
+				{"task_key": "b", "depends_on": [{"task_key": "a"}]}
+			]
+		}
+EOT
+`,
+	}.ExpectError(t, "tasks have circular dependency: a -> b -> a")
+}
+
+func TestJobDefinitionField(t *testing.T) {
+	assert.Equal(t, "tasks", jobDefinitionField("task"))
+	assert.Equal(t, "job_clusters", jobDefinitionField("job_cluster"))
+	assert.Equal(t, "max_retries", jobDefinitionField("max_retries"))
+}
+
+func TestResourceJobUpdate_JobDefinitionJSONRunOnApply(t *testing.T) {
+	definition := func(name, notebook string) string {
+		return `{"name": "` + name + `", "tasks": [{"task_key": "a", "existing_cluster_id": "abc", ` +
+			`"notebook_task": {"notebook_path": "` + notebook + `"}}]}`
+	}
+	for _, tc := range []struct {
+		name      string
+		notebook  string
+		triggered bool
+	}{
+		{"Renamed", "/a", false},
+		{"Featurizer", "/b", true},
+	} {
+		fixtures := []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/reset",
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.1/jobs/get?job_id=789",
+				ReuseRequest: true,
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name: tc.name,
+						Tasks: []JobTaskSettings{
+							{
+								TaskKey:           "a",
+								ExistingClusterID: "abc",
+								NotebookTask: &NotebookTask{
+									NotebookPath: tc.notebook,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		if tc.triggered {
+			fixtures = append(fixtures, qa.HTTPFixture{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/run-now",
+				Response: JobRun{
+					RunID: 890,
+				},
+			}, qa.HTTPFixture{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/get?run_id=890",
+				Response: JobRun{
+					RunID: 890,
+					State: RunState{
+						LifeCycleState: "TERMINATED",
+						ResultState:    "SUCCESS",
+					},
+				},
+			})
+		}
+		d, err := qa.ResourceFixture{
+			Fixtures: fixtures,
+			ID:       "789",
+			Update:   true,
+			Resource: ResourceJob(),
+			InstanceState: map[string]string{
+				"job_definition_json":        definition("Featurizer", "/a"),
+				"run_on_apply.#":             "1",
+				"run_on_apply.0.on_change.#": "1",
+				fmt.Sprintf("run_on_apply.0.on_change.%d", schema.HashString("task")): "task",
+				"last_run_id":    "123",
+				"last_run_state": "SUCCESS",
+			},
+			HCL: `
+			job_definition_json = <<EOT
+` + definition(tc.name, tc.notebook) + `
+EOT
+			run_on_apply {
+				on_change = ["task"]
+			}`,
+		}.Apply(t)
+		require.NoError(t, err, tc.name)
+		expectedRunID := 123
+		if tc.triggered {
+			expectedRunID = 890
+		}
+		assert.Equal(t, expectedRunID, d.Get("last_run_id"), tc.name)
+	}
+}
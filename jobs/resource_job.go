@@ -364,13 +364,17 @@ type RunState struct {
 
 // JobRun is a simplified representation of corresponding entity
 type JobRun struct {
-	JobID       int64    `json:"job_id,omitempty"`
-	RunID       int64    `json:"run_id,omitempty"`
-	NumberInJob int64    `json:"number_in_job,omitempty"`
-	StartTime   int64    `json:"start_time,omitempty"`
-	State       RunState `json:"state,omitempty"`
-	Trigger     string   `json:"trigger,omitempty"`
-	RuntType    string   `json:"run_type,omitempty"`
+	JobID       int64        `json:"job_id,omitempty"`
+	RunID       int64        `json:"run_id,omitempty"`
+	NumberInJob int64        `json:"number_in_job,omitempty"`
+	StartTime   int64        `json:"start_time,omitempty"`
+	EndTime     int64        `json:"end_time,omitempty"`
+	RunName     string       `json:"run_name,omitempty"`
+	State       RunState     `json:"state,omitempty"`
+	Trigger     string       `json:"trigger,omitempty"`
+	RuntType    string       `json:"run_type,omitempty"`
+	RunPageURL  string       `json:"run_page_url,omitempty"`
+	Tasks       []JobRunTask `json:"tasks,omitempty"`
 
 	OverridingParameters RunParameters  `json:"overriding_parameters,omitempty"`
 	JobParameters        []JobParameter `json:"job_parameters,omitempty"`
@@ -383,6 +387,8 @@ type JobRunsListRequest struct {
 	CompletedOnly bool  `url:"completed_only,omitempty"`
 	Offset        int32 `url:"offset,omitempty"`
 	Limit         int32 `url:"limit,omitempty"`
+	StartTimeFrom int64 `url:"start_time_from,omitempty"`
+	StartTimeTo   int64 `url:"start_time_to,omitempty"`
 }
 
 // JobRunsList returns a page of job runs
@@ -464,6 +470,27 @@ func (a JobsAPI) RunsCancel(runID int64, timeout time.Duration) error {
 	return a.waitForRunState(runID, "TERMINATED", timeout)
 }
 
+// waitForRunFinishedOrCancel waits until the run is finished and cancels it, if it's not finished
+// within the timeout, without waiting for its termination.
+func (a JobsAPI) waitForRunFinishedOrCancel(runID int64, timeout time.Duration) (JobRun, error) {
+	jobRun, err := a.waitForRunFinished(runID, timeout)
+	if err == nil {
+		return jobRun, nil
+	}
+	// the context of the request could be already expired by the timeout
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	ctx = context.WithValue(ctx, common.Api, a.context.Value(common.Api))
+	var response any
+	cancelErr := a.client.Post(ctx, "/jobs/runs/cancel", map[string]any{
+		"run_id": runID,
+	}, &response)
+	if cancelErr != nil {
+		return jobRun, fmt.Errorf("%w, and cannot cancel run %d: %v", err, runID, cancelErr)
+	}
+	return jobRun, fmt.Errorf("%w, run %d is cancelled", err, runID)
+}
+
 func (a JobsAPI) waitForRunState(runID int64, desiredState string, timeout time.Duration) error {
 	return resource.RetryContext(a.context, timeout, func() *resource.RetryError {
 		jobRun, err := a.RunsGet(runID)
@@ -487,6 +514,26 @@ func (a JobsAPI) waitForRunState(runID int64, desiredState string, timeout time.
 	})
 }
 
+// waitForRunFinished waits until the run reaches one of the terminal states and returns it
+func (a JobsAPI) waitForRunFinished(runID int64, timeout time.Duration) (jobRun JobRun, err error) {
+	err = resource.RetryContext(a.context, timeout, func() *resource.RetryError {
+		jobRun, err = a.RunsGet(runID)
+		if err != nil {
+			return resource.NonRetryableError(
+				fmt.Errorf("cannot get run %d: %v", runID, err))
+		}
+		switch jobRun.State.LifeCycleState {
+		case "TERMINATED", "SKIPPED", "INTERNAL_ERROR":
+			return nil
+		}
+		return resource.RetryableError(
+			fmt.Errorf("run is %s: %s",
+				jobRun.State.LifeCycleState,
+				jobRun.State.StateMessage))
+	})
+	return
+}
+
 // RunNow triggers the job and returns a run ID
 func (a JobsAPI) RunNow(jobID int64) (int64, error) {
 	var jr JobRun
@@ -658,6 +705,11 @@ func gitSourceSchema(r *schema.Resource, prefix string) {
 
 var jobSchema = common.StructToSchema(JobSettings{},
 	func(s map[string]*schema.Schema) map[string]*schema.Schema {
+		var settingsKeys []string
+		for k := range s {
+			settingsKeys = append(settingsKeys, k)
+		}
+		sort.Strings(settingsKeys)
 		jobSettingsSchema(&s, "")
 		jobSettingsSchema(&s["task"].Elem.(*schema.Resource).Schema, "task.0.")
 		jobSettingsSchema(&s["job_cluster"].Elem.(*schema.Resource).Schema, "job_cluster.0.")
@@ -677,18 +729,56 @@ var jobSchema = common.StructToSchema(JobSettings{},
 			Type:     schema.TypeString,
 			Computed: true,
 		}
+		s["job_definition_json"] = &schema.Schema{
+			Optional:         true,
+			Type:             schema.TypeString,
+			ValidateFunc:     validateJobDefinition,
+			DiffSuppressFunc: suppressJobDefinitionDiff,
+			ConflictsWith:    settingsKeys,
+		}
 		s["always_running"] = &schema.Schema{
 			Optional:      true,
 			Default:       false,
 			Type:          schema.TypeBool,
 			Deprecated:    "always_running will be replaced by control_run_state in the next major release.",
-			ConflictsWith: []string{"control_run_state", "continuous"},
+			ConflictsWith: []string{"control_run_state", "continuous", "run_on_apply"},
 		}
 		s["control_run_state"] = &schema.Schema{
 			Optional:      true,
 			Default:       false,
 			Type:          schema.TypeBool,
-			ConflictsWith: []string{"always_running"},
+			ConflictsWith: []string{"always_running", "run_on_apply"},
+		}
+		s["run_on_apply"] = &schema.Schema{
+			Optional: true,
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"on_change": {
+						Optional: true,
+						Type:     schema.TypeSet,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+							ValidateFunc: validation.StringInSlice(
+								append([]string{"job_definition_json"}, settingsKeys...), false),
+						},
+					},
+				},
+			},
+			ConflictsWith: []string{"always_running", "control_run_state", "continuous"},
+		}
+		s["last_run_id"] = &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		}
+		s["last_run_state"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		}
+		s["last_run_page_url"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
 		}
 		s["schedule"].ConflictsWith = []string{"continuous", "trigger"}
 		s["continuous"].ConflictsWith = []string{"schedule", "trigger"}
@@ -712,7 +802,8 @@ func parseJobId(id string) (int64, error) {
 //  1. always_running: When enabled, a new run will be started after the job configuration is updated.
 //     An existing active run will be cancelled if one exists.
 //  2. control_run_state: When enabled, stops the active run of continuous jobs after the job configuration is updated.
-//  3. Noop: No lifecycle management.
+//  3. run_on_apply: When enabled, runs the job after creation and update, and waits for the run to succeed.
+//  4. Noop: No lifecycle management.
 //
 // always_running is deprecated but still supported for backwards compatibility.
 type jobLifecycleManager interface {
@@ -727,6 +818,9 @@ func getJobLifecycleManager(d *schema.ResourceData, m any) jobLifecycleManager {
 	if d.Get("control_run_state").(bool) {
 		return controlRunStateLifecycleManager{d: d, m: m}
 	}
+	if _, ok := d.GetOk("run_on_apply"); ok {
+		return runOnApplyLifecycleManager{d: d, m: m}
+	}
 	return noopLifecycleManager{}
 }
 
@@ -808,6 +902,92 @@ func (c controlRunStateLifecycleManager) OnUpdate(ctx context.Context) error {
 	return api.StopActiveRun(jobID, c.d.Timeout(schema.TimeoutUpdate))
 }
 
+type runOnApplyLifecycleManager struct {
+	d *schema.ResourceData
+	m any
+}
+
+func (r runOnApplyLifecycleManager) OnCreate(ctx context.Context) error {
+	return r.run(ctx, r.d.Timeout(schema.TimeoutCreate))
+}
+
+func (r runOnApplyLifecycleManager) OnUpdate(ctx context.Context) error {
+	if !runOnApplyTriggered(r.d) {
+		return nil
+	}
+	return r.run(ctx, r.d.Timeout(schema.TimeoutUpdate))
+}
+
+// run triggers the job and fails, if the run doesn't succeed, so that resources
+// depending on the job are not applied
+func (r runOnApplyLifecycleManager) run(ctx context.Context, timeout time.Duration) error {
+	jobID, err := parseJobId(r.d.Id())
+	if err != nil {
+		return err
+	}
+	api := NewJobsAPI(ctx, r.m)
+	runID, err := api.RunNow(jobID)
+	if err != nil {
+		return fmt.Errorf("cannot start job run: %v", err)
+	}
+	r.d.Set("last_run_id", runID)
+	r.d.Set("last_run_state", "")
+	r.d.Set("last_run_page_url", "")
+	jobRun, err := api.waitForRunFinished(runID, timeout)
+	if err != nil {
+		return err
+	}
+	state := jobRun.State.ResultState
+	if state == "" {
+		state = jobRun.State.LifeCycleState
+	}
+	r.d.Set("last_run_state", state)
+	r.d.Set("last_run_page_url", jobRun.RunPageURL)
+	if state != "SUCCESS" {
+		return fmt.Errorf("run %d of job %d finished with %s: %s %s",
+			runID, jobID, state, jobRun.State.StateMessage, jobRun.RunPageURL)
+	}
+	return nil
+}
+
+type changeDetector interface {
+	Get(string) any
+	GetChange(string) (any, any)
+	HasChange(string) bool
+}
+
+// lastRunFailed tells if the previous run started by `run_on_apply` didn't succeed, e.g. failed or timed out,
+// so that the next apply runs the job again, even without changes of the job
+func lastRunFailed(d changeDetector) bool {
+	lastRunID, _ := d.GetChange("last_run_id")
+	lastRunState, _ := d.GetChange("last_run_state")
+	return lastRunID.(int) != 0 && lastRunState.(string) != "SUCCESS"
+}
+
+// runOnApplyTriggered tells if the job has to run after the update. When `on_change` is not specified,
+// any change of the job triggers a run. Arguments of `job_definition_json` are compared within the JSON.
+func runOnApplyTriggered(d changeDetector) bool {
+	if lastRunFailed(d) {
+		return true
+	}
+	onChange := d.Get("run_on_apply.0.on_change").(*schema.Set).List()
+	if len(onChange) == 0 {
+		return true
+	}
+	oldDefinition, newDefinition := d.GetChange("job_definition_json")
+	isDefinition := oldDefinition.(string) != "" || newDefinition.(string) != ""
+	for _, attr := range onChange {
+		key := attr.(string)
+		if d.HasChange(key) {
+			return true
+		}
+		if isDefinition && key != "job_definition_json" && jobDefinitionChanged(d, key) {
+			return true
+		}
+	}
+	return false
+}
+
 func prepareJobSettingsForUpdate(d *schema.ResourceData, js JobSettings) {
 	if js.NewCluster != nil {
 		js.NewCluster.ModifyRequestOnInstancePool()
@@ -827,11 +1007,60 @@ func prepareJobSettingsForUpdate(d *schema.ResourceData, js JobSettings) {
 	}
 }
 
+// removePolicyDefaultValues prevents configuration drift on attributes of job clusters with
+// `apply_policy_default_values`, that were set from the cluster policy by the backend
+func removePolicyDefaultValues(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient,
+	js *JobSettings) error {
+	var configured JobSettings
+	common.DataToStructPointer(d, jobSchema, &configured)
+	definitions := map[string]clusters.PolicyDefinition{}
+	remove := func(configured, actual *clusters.Cluster) error {
+		if configured == nil || actual == nil || !configured.ApplyPolicyDefaultValues || configured.PolicyID == "" {
+			return nil
+		}
+		pd, ok := definitions[configured.PolicyID]
+		if !ok {
+			var err error
+			pd, err = clusters.GetPolicyDefinition(ctx, c, configured.PolicyID)
+			if err != nil {
+				log.Printf("[WARN] Can't get cluster policy %s: %v", configured.PolicyID, err)
+			}
+			// the policy is fetched only once, even if it can't be fetched
+			definitions[configured.PolicyID] = pd
+		}
+		if pd == nil {
+			return nil
+		}
+		return clusters.RemovePolicyDefaultValues(pd, *configured, actual)
+	}
+	if err := remove(configured.NewCluster, js.NewCluster); err != nil {
+		return err
+	}
+	configuredJobClusters := map[string]*clusters.Cluster{}
+	for _, jc := range configured.JobClusters {
+		configuredJobClusters[jc.JobClusterKey] = jc.NewCluster
+	}
+	for _, jc := range js.JobClusters {
+		if err := remove(configuredJobClusters[jc.JobClusterKey], jc.NewCluster); err != nil {
+			return err
+		}
+	}
+	configuredTasks := map[string]*clusters.Cluster{}
+	for _, task := range configured.Tasks {
+		configuredTasks[task.TaskKey] = task.NewCluster
+	}
+	for _, task := range js.Tasks {
+		if err := remove(configuredTasks[task.TaskKey], task.NewCluster); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func ResourceJob() *schema.Resource {
 	getReadCtx := func(ctx context.Context, d *schema.ResourceData) context.Context {
-		var js JobSettings
-		common.DataToStructPointer(d, jobSchema, &js)
-		if js.isMultiTask() {
+		js, err := dataToJobSettings(d)
+		if err == nil && js.isMultiTask() {
 			return context.WithValue(ctx, common.Api, common.API_2_1)
 		}
 		return ctx
@@ -844,8 +1073,10 @@ func ResourceJob() *schema.Resource {
 			Update: schema.DefaultTimeout(clusters.DefaultProvisionTimeout),
 		},
 		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff) error {
-			var js JobSettings
-			common.DiffToStructPointer(d, jobSchema, &js)
+			js, err := diffToJobSettings(d)
+			if err != nil {
+				return err
+			}
 			alwaysRunning := d.Get("always_running").(bool)
 			if alwaysRunning && js.MaxConcurrentRuns > 1 {
 				return fmt.Errorf("`always_running` must be specified only with `max_concurrent_runs = 1`")
@@ -859,6 +1090,17 @@ func ResourceJob() *schema.Resource {
 					return fmt.Errorf("`control_run_state` must be specified only with `max_concurrent_runs = 1`")
 				}
 			}
+			if err := js.validateTasks(); err != nil {
+				return err
+			}
+			if _, ok := d.GetOk("run_on_apply"); ok && (lastRunFailed(d) ||
+				d.Id() != "" && len(d.GetChangedKeysPrefix("")) > 0 && runOnApplyTriggered(d)) {
+				for _, attr := range []string{"last_run_id", "last_run_state", "last_run_page_url"} {
+					if err := d.SetNewComputed(attr); err != nil {
+						return err
+					}
+				}
+			}
 			for _, task := range js.Tasks {
 				if task.NewCluster == nil {
 					continue
@@ -874,9 +1116,40 @@ func ResourceJob() *schema.Resource {
 			}
 			return nil
 		},
+		CustomizeDiffWithClient: func(ctx context.Context, d *schema.ResourceDiff, c *common.DatabricksClient) error {
+			if d.Id() != "" && len(d.GetChangedKeysPrefix("")) == 0 {
+				return nil
+			}
+			js, err := diffToJobSettings(d)
+			if err != nil {
+				return err
+			}
+			checker := clusters.NewClusterPolicyChecker(ctx, c, d)
+			var violations []string
+			check := func(prefix, location string, cluster *clusters.Cluster) {
+				if cluster == nil {
+					return
+				}
+				for _, v := range checker.Check(prefix, *cluster, clusters.ClusterTypeJob) {
+					violations = append(violations, fmt.Sprintf("%s: %s", location, v))
+				}
+			}
+			check("new_cluster.0.", "new_cluster", js.NewCluster)
+			for i, jc := range js.JobClusters {
+				check(fmt.Sprintf("job_cluster.%d.new_cluster.0.", i),
+					fmt.Sprintf("job cluster %s", jc.JobClusterKey), jc.NewCluster)
+			}
+			for i, task := range js.Tasks {
+				check(fmt.Sprintf("task.%d.new_cluster.0.", i),
+					fmt.Sprintf("task %s", task.TaskKey), task.NewCluster)
+			}
+			return clusters.PolicyViolationsError(violations)
+		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			var js JobSettings
-			common.DataToStructPointer(d, jobSchema, &js)
+			js, err := dataToJobSettings(d)
+			if err != nil {
+				return err
+			}
 			if js.isMultiTask() {
 				ctx = context.WithValue(ctx, common.Api, common.API_2_1)
 			}
@@ -895,11 +1168,19 @@ func ResourceJob() *schema.Resource {
 				return err
 			}
 			d.Set("url", c.FormatURL("#job/", d.Id()))
+			if err = removePolicyDefaultValues(ctx, d, c, job.Settings); err != nil {
+				return err
+			}
+			if _, ok := d.GetOk("job_definition_json"); ok {
+				return readJobDefinition(d, *job.Settings)
+			}
 			return common.StructToData(*job.Settings, jobSchema, d)
 		},
 		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			var js JobSettings
-			common.DataToStructPointer(d, jobSchema, &js)
+			js, err := dataToJobSettings(d)
+			if err != nil {
+				return err
+			}
 			if js.isMultiTask() {
 				ctx = context.WithValue(ctx, common.Api, common.API_2_1)
 			}
@@ -907,7 +1188,7 @@ func ResourceJob() *schema.Resource {
 			prepareJobSettingsForUpdate(d, js)
 
 			jobsAPI := NewJobsAPI(ctx, c)
-			err := jobsAPI.Update(d.Id(), js)
+			err = jobsAPI.Update(d.Id(), js)
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,194 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/databricks/terraform-provider-databricks/clusters"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// JobRunTaskOutput is the output of a single task of a submitted run
+type JobRunTaskOutput struct {
+	TaskKey                 string `json:"task_key,omitempty" tf:"computed"`
+	RunID                   int64  `json:"run_id,omitempty" tf:"computed"`
+	ResultState             string `json:"result_state,omitempty" tf:"computed"`
+	NotebookOutputResult    string `json:"notebook_output_result,omitempty" tf:"computed"`
+	NotebookOutputTruncated bool   `json:"notebook_output_truncated,omitempty" tf:"computed"`
+	Error                   string `json:"error,omitempty" tf:"computed"`
+	ErrorTrace              string `json:"error_trace,omitempty" tf:"computed"`
+}
+
+// JobRunSubmission is a one-time run of tasks, that is not saved as a job
+type JobRunSubmission struct {
+	RunName          string            `json:"run_name,omitempty"`
+	Tasks            []JobTaskSettings `json:"tasks,omitempty" tf:"alias:task"`
+	JobClusters      []JobCluster      `json:"job_clusters,omitempty" tf:"alias:job_cluster"`
+	GitSource        *GitSource        `json:"git_source,omitempty"`
+	TimeoutSeconds   int32             `json:"timeout_seconds,omitempty"`
+	IdempotencyToken string            `json:"idempotency_token,omitempty"`
+
+	RunID          int64              `json:"run_id,omitempty" tf:"computed"`
+	RunPageURL     string             `json:"run_page_url,omitempty" tf:"computed"`
+	LifeCycleState string             `json:"life_cycle_state,omitempty" tf:"computed"`
+	ResultState    string             `json:"result_state,omitempty" tf:"computed"`
+	StateMessage   string             `json:"state_message,omitempty" tf:"computed"`
+	TaskOutputs    []JobRunTaskOutput `json:"task_output,omitempty" tf:"computed"`
+}
+
+// JobRunTask is a task of a job run
+type JobRunTask struct {
+	TaskKey string   `json:"task_key,omitempty"`
+	RunID   int64    `json:"run_id,omitempty"`
+	State   RunState `json:"state,omitempty"`
+}
+
+// SubmitRun submits a one-time run and returns a run ID
+func (a JobsAPI) SubmitRun(submission JobRunSubmission) (int64, error) {
+	var jr JobRun
+	err := a.client.Post(a.context, "/jobs/runs/submit", submission, &jr)
+	return jr.RunID, err
+}
+
+// RunsGetOutput retrieves output of a single task run
+func (a JobsAPI) RunsGetOutput(runID int64) (jobs.RunOutput, error) {
+	var output jobs.RunOutput
+	err := a.client.Get(a.context, "/jobs/runs/get-output", map[string]any{
+		"run_id": runID,
+	}, &output)
+	return output, err
+}
+
+// setRunState sets state of the run and outputs of its tasks
+func (js *JobRunSubmission) setRunState(api JobsAPI, jobRun JobRun) error {
+	js.RunPageURL = jobRun.RunPageURL
+	js.LifeCycleState = jobRun.State.LifeCycleState
+	js.ResultState = jobRun.State.ResultState
+	js.StateMessage = jobRun.State.StateMessage
+	js.TaskOutputs = []JobRunTaskOutput{}
+	for _, task := range jobRun.Tasks {
+		output := JobRunTaskOutput{
+			TaskKey:     task.TaskKey,
+			RunID:       task.RunID,
+			ResultState: task.State.ResultState,
+		}
+		if task.State.ResultState != "" {
+			out, err := api.RunsGetOutput(task.RunID)
+			if err != nil {
+				return fmt.Errorf("cannot get output of task %s: %w", task.TaskKey, err)
+			}
+			if out.NotebookOutput != nil {
+				output.NotebookOutputResult = out.NotebookOutput.Result
+				output.NotebookOutputTruncated = out.NotebookOutput.Truncated
+			}
+			output.Error = out.Error
+			output.ErrorTrace = out.ErrorTrace
+		}
+		js.TaskOutputs = append(js.TaskOutputs, output)
+	}
+	return nil
+}
+
+var jobRunSchema = common.StructToSchema(JobRunSubmission{},
+	func(s map[string]*schema.Schema) map[string]*schema.Schema {
+		jobSettingsSchema(&s["task"].Elem.(*schema.Resource).Schema, "task.0.")
+		jobSettingsSchema(&s["job_cluster"].Elem.(*schema.Resource).Schema, "job_cluster.0.")
+		gitSourceSchema(s["git_source"].Elem.(*schema.Resource), "")
+		s["task"].MinItems = 1
+		s["timeout_seconds"].ValidateDiagFunc = validation.ToDiagFunc(validation.IntAtLeast(0))
+		return s
+	})
+
+// ResourceJobRun submits a one-time run, waits for its completion and stores outputs of tasks.
+// Runs can't be changed, so every change of the configuration submits a new run.
+func ResourceJobRun() *schema.Resource {
+	return common.Resource{
+		Schema: jobRunSchema,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(clusters.DefaultProvisionTimeout),
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff) error {
+			var submission JobRunSubmission
+			common.DiffToStructPointer(d, jobRunSchema, &submission)
+			js := JobSettings{
+				Tasks:       submission.Tasks,
+				JobClusters: submission.JobClusters,
+			}
+			if err := js.validateTasks(); err != nil {
+				return err
+			}
+			for _, task := range submission.Tasks {
+				if task.NewCluster == nil {
+					continue
+				}
+				if err := task.NewCluster.Validate(); err != nil {
+					return fmt.Errorf("task %s invalid: %w", task.TaskKey, err)
+				}
+			}
+			return nil
+		},
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var submission JobRunSubmission
+			common.DataToStructPointer(d, jobRunSchema, &submission)
+			ctx = context.WithValue(ctx, common.Api, common.API_2_1)
+			api := NewJobsAPI(ctx, c)
+			runID, err := api.SubmitRun(submission)
+			if err != nil {
+				return err
+			}
+			// the run is stored in the state only when it's finished, as otherwise
+			// the next apply would submit a run in parallel with this one
+			jobRun, err := api.waitForRunFinishedOrCancel(runID, d.Timeout(schema.TimeoutCreate))
+			if err != nil {
+				return err
+			}
+			d.SetId(fmt.Sprint(runID))
+			if err = submission.setRunState(api, jobRun); err != nil {
+				return err
+			}
+			submission.RunID = runID
+			if err = common.StructToData(submission, jobRunSchema, d); err != nil {
+				return err
+			}
+			if jobRun.State.ResultState != "SUCCESS" {
+				return fmt.Errorf("run %d finished with %s: %s %s", runID,
+					jobRun.State.ResultState, jobRun.State.StateMessage, jobRun.RunPageURL)
+			}
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			runID, err := strconv.ParseInt(d.Id(), 10, 64)
+			if err != nil {
+				return err
+			}
+			ctx = context.WithValue(ctx, common.Api, common.API_2_1)
+			api := NewJobsAPI(ctx, c)
+			jobRun, err := api.RunsGet(runID)
+			if apierr.IsMissing(err) {
+				// runs are removed from the history after some time, but they shouldn't be submitted again
+				log.Printf("[INFO] Run %d is not in the history of runs anymore", runID)
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			var submission JobRunSubmission
+			common.DataToStructPointer(d, jobRunSchema, &submission)
+			if err = submission.setRunState(api, jobRun); err != nil {
+				return err
+			}
+			submission.RunID = runID
+			return common.StructToData(submission, jobRunSchema, d)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			// runs can't be undone, so they're only removed from the state and stay in the history of runs
+			return nil
+		},
+	}.ToResource()
+}
@@ -0,0 +1,299 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+var jobRunHCL = `
+run_name = "Backfill"
+task {
+	task_key = "backfill"
+	existing_cluster_id = "abc"
+	notebook_task {
+		notebook_path = "/Shared/backfill"
+	}
+}
+`
+
+var finishedJobRun = JobRun{
+	RunID:      123,
+	RunPageURL: "https://example.com/#job/1/run/123",
+	State: RunState{
+		LifeCycleState: "TERMINATED",
+		ResultState:    "SUCCESS",
+		StateMessage:   "",
+	},
+	Tasks: []JobRunTask{
+		{
+			TaskKey: "backfill",
+			RunID:   124,
+			State: RunState{
+				LifeCycleState: "TERMINATED",
+				ResultState:    "SUCCESS",
+			},
+		},
+	},
+}
+
+func TestResourceJobRunCreate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/runs/submit",
+				ExpectedRequest: JobRunSubmission{
+					RunName: "Backfill",
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey:           "backfill",
+							ExistingClusterID: "abc",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/Shared/backfill",
+							},
+						},
+					},
+				},
+				Response: JobRun{
+					RunID: 123,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/get?run_id=123",
+				Response: JobRun{
+					RunID: 123,
+					State: RunState{
+						LifeCycleState: "PENDING",
+					},
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.1/jobs/runs/get?run_id=123",
+				ReuseRequest: true,
+				Response:     finishedJobRun,
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.1/jobs/runs/get-output?run_id=124",
+				ReuseRequest: true,
+				Response: jobs.RunOutput{
+					NotebookOutput: &jobs.NotebookOutput{
+						Result: "42 rows",
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJobRun(),
+		HCL:      jobRunHCL,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                                   "123",
+		"run_id":                               123,
+		"run_page_url":                         "https://example.com/#job/1/run/123",
+		"life_cycle_state":                     "TERMINATED",
+		"result_state":                         "SUCCESS",
+		"task_output.#":                        1,
+		"task_output.0.task_key":               "backfill",
+		"task_output.0.run_id":                 124,
+		"task_output.0.notebook_output_result": "42 rows",
+	})
+}
+
+func TestResourceJobRunCreate_Failed(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/runs/submit",
+				Response: JobRun{
+					RunID: 123,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/get?run_id=123",
+				Response: JobRun{
+					RunID:      123,
+					RunPageURL: "https://example.com/#job/1/run/123",
+					State: RunState{
+						LifeCycleState: "TERMINATED",
+						ResultState:    "FAILED",
+						StateMessage:   "Task backfill failed",
+					},
+					Tasks: []JobRunTask{
+						{
+							TaskKey: "backfill",
+							RunID:   124,
+							State: RunState{
+								LifeCycleState: "TERMINATED",
+								ResultState:    "FAILED",
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/get-output?run_id=124",
+				Response: jobs.RunOutput{
+					Error:      "ZeroDivisionError: division by zero",
+					ErrorTrace: "Traceback ...",
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJobRun(),
+		HCL:      jobRunHCL,
+	}.ExpectError(t, "run 123 finished with FAILED: Task backfill failed https://example.com/#job/1/run/123")
+}
+
+func TestResourceJobRunCreate_CancelledWhenNotFinished(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/runs/submit",
+				Response: JobRun{
+					RunID: 123,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/get?run_id=123",
+				Status:   500,
+				Response: apierr.APIErrorBody{
+					ErrorCode: "INTERNAL_ERROR",
+					Message:   "nope",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/runs/cancel",
+				ExpectedRequest: map[string]any{
+					"run_id": 123,
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJobRun(),
+		HCL:      jobRunHCL,
+	}.Apply(t)
+	assert.ErrorContains(t, err, "cannot get run 123: nope, run 123 is cancelled")
+	assert.Equal(t, "", d.Id(), "unfinished run must not be stored in the state")
+}
+
+func TestWaitForRunFinishedOrCancel_Timeout(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			Resource:     "/api/2.1/jobs/runs/get?run_id=123",
+			ReuseRequest: true,
+			Response: JobRun{
+				RunID: 123,
+				State: RunState{
+					LifeCycleState: "RUNNING",
+				},
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.1/jobs/runs/cancel",
+			ExpectedRequest: map[string]any{
+				"run_id": 123,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		ctx = context.WithValue(ctx, common.Api, common.API_2_1)
+		_, err := NewJobsAPI(ctx, client).waitForRunFinishedOrCancel(123, time.Second)
+		assert.ErrorContains(t, err, "run 123 is cancelled")
+	})
+}
+
+func TestResourceJobRunCreate_InvalidTasks(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJobRun(),
+		HCL: jobRunHCL + `
+		task {
+			task_key = "report"
+			existing_cluster_id = "abc"
+			depends_on {
+				task_key = "backfil"
+			}
+			notebook_task {
+				notebook_path = "/Shared/report"
+			}
+		}`,
+	}.ExpectError(t, "task report depends on unknown task backfil")
+}
+
+func TestResourceJobRunRead(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/get?run_id=123",
+				Response: finishedJobRun,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/get-output?run_id=124",
+				Response: jobs.RunOutput{
+					NotebookOutput: &jobs.NotebookOutput{
+						Result:    "42 rows",
+						Truncated: true,
+					},
+				},
+			},
+		},
+		Read:     true,
+		Resource: ResourceJobRun(),
+		ID:       "123",
+		HCL:      jobRunHCL,
+		New:      true,
+	}.ApplyAndExpectData(t, map[string]any{
+		"run_name":                                "Backfill",
+		"result_state":                            "SUCCESS",
+		"task_output.0.notebook_output_result":    "42 rows",
+		"task_output.0.notebook_output_truncated": true,
+	})
+}
+
+func TestResourceJobRunRead_RemovedFromHistory(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/get?run_id=123",
+				Status:   404,
+				Response: apierr.NotFound("Run 123 does not exist."),
+			},
+		},
+		Read:     true,
+		Resource: ResourceJobRun(),
+		ID:       "123",
+		HCL:      jobRunHCL,
+		New:      true,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":       "123",
+		"run_name": "Backfill",
+	})
+}
+
+func TestResourceJobRunDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Delete:   true,
+		Resource: ResourceJobRun(),
+		ID:       "123",
+		HCL:      jobRunHCL,
+	}.ApplyNoError(t)
+}
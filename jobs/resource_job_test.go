@@ -15,6 +15,7 @@ import (
 	"github.com/databricks/terraform-provider-databricks/libraries"
 	"github.com/databricks/terraform-provider-databricks/qa"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -888,6 +889,54 @@ func TestResourceJobCreate_AlwaysRunning_Conflict(t *testing.T) {
 	}.ExpectError(t, "`always_running` must be specified only with `max_concurrent_runs = 1`")
 }
 
+func TestResourceJobCreate_ClusterPolicyViolations(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: compute.Policy{
+					PolicyId:   "abc",
+					Definition: `{"cluster_type": {"type": "fixed", "value": "job"}, "num_workers": {"type": "range", "maxValue": 4}}`,
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		job_cluster {
+			job_cluster_key = "shared"
+			new_cluster {
+				spark_version = "13.3.x-scala2.12"
+				node_type_id = "i3.xlarge"
+				num_workers = 8
+				policy_id = "abc"
+			}
+		}
+		task {
+			task_key = "a"
+			job_cluster_key = "shared"
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+		}
+		task {
+			task_key = "b"
+			new_cluster {
+				spark_version = "13.3.x-scala2.12"
+				node_type_id = "i3.xlarge"
+				num_workers = 10
+				policy_id = "abc"
+			}
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+		}`,
+	}.ExpectError(t, "cluster doesn't comply with the cluster policy: "+
+		"job cluster shared: num_workers: value 8 is greater than maximum 4; "+
+		"task b: num_workers: value 10 is greater than maximum 4")
+}
+
 func TestResourceJobCreate_ControlRunState_AlwaysRunningConflict(t *testing.T) {
 	qa.ResourceFixture{
 		Create:   true,
@@ -908,6 +957,28 @@ func TestResourceJobCreate_ControlRunState_NoContinuous(t *testing.T) {
 	}.ExpectError(t, "`control_run_state` must be specified only with `continuous`")
 }
 
+func TestResourceJobCreate_TaskDependencyTypo(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		job_cluster {
+			job_cluster_key = "shared"
+		}
+		task {
+			task_key = "ingest"
+			job_cluster_key = "shared"
+		}
+		task {
+			task_key = "transform"
+			job_cluster_key = "shared"
+			depends_on {
+				task_key = "injest"
+			}
+		}`,
+	}.ExpectError(t, "task transform depends on unknown task injest")
+}
+
 func TestResourceJobCreate_ControlRunState_ContinuousCreate(t *testing.T) {
 	qa.ResourceFixture{
 		Create:   true,
@@ -1575,6 +1646,132 @@ func TestResourceJobRead(t *testing.T) {
 	assert.Equal(t, "abc", d.Get("existing_cluster_id"))
 }
 
+func TestResourceJobRead_ApplyPolicyDefaultValues(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: compute.Policy{
+					PolicyId:   "abc",
+					Definition: `{"custom_tags.team": {"type": "fixed", "value": "data"}}`,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name: "Featurizer",
+						Tasks: []JobTaskSettings{
+							{
+								TaskKey: "a",
+								NewCluster: &clusters.Cluster{
+									SparkVersion:             "13.3.x-scala2.12",
+									NumWorkers:               1,
+									PolicyID:                 "abc",
+									ApplyPolicyDefaultValues: true,
+									CustomTags:               map[string]string{"team": "data"},
+								},
+								NotebookTask: &NotebookTask{
+									NotebookPath: "/Stuff",
+								},
+							},
+						},
+						MaxConcurrentRuns: 1,
+					},
+				},
+			},
+		},
+		Resource: ResourceJob(),
+		Read:     true,
+		New:      true,
+		ID:       "789",
+		HCL: `
+		task {
+			task_key = "a"
+			new_cluster {
+				spark_version = "13.3.x-scala2.12"
+				num_workers = 1
+				policy_id = "abc"
+				apply_policy_default_values = true
+			}
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+		}`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", d.Get("task.0.new_cluster.0.policy_id"))
+	assert.Equal(t, map[string]any{}, d.Get("task.0.new_cluster.0.custom_tags"))
+}
+
+func TestResourceJobRead_ApplyPolicyDefaultValuesPolicyNotAvailable(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/policies/clusters/get?policy_id=abc",
+				Status:       404,
+				Response: apierr.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Policy abc does not exist",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name: "Featurizer",
+						Tasks: []JobTaskSettings{
+							{
+								TaskKey: "a",
+								NewCluster: &clusters.Cluster{
+									SparkVersion:             "13.3.x-scala2.12",
+									NumWorkers:               1,
+									PolicyID:                 "abc",
+									ApplyPolicyDefaultValues: true,
+									CustomTags:               map[string]string{"team": "data"},
+								},
+								NotebookTask: &NotebookTask{
+									NotebookPath: "/Stuff",
+								},
+							},
+						},
+						MaxConcurrentRuns: 1,
+					},
+				},
+			},
+		},
+		Resource: ResourceJob(),
+		Read:     true,
+		New:      true,
+		ID:       "789",
+		HCL: `
+		task {
+			task_key = "a"
+			new_cluster {
+				spark_version = "13.3.x-scala2.12"
+				num_workers = 1
+				policy_id = "abc"
+				apply_policy_default_values = true
+			}
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+		}`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", d.Get("task.0.new_cluster.0.policy_id"))
+	// values aren't removed without the policy definition
+	assert.Equal(t, map[string]any{"team": "data"}, d.Get("task.0.new_cluster.0.custom_tags"))
+}
+
 func TestResourceJobRead_NotFound(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -2375,3 +2572,280 @@ func TestJobResource_SparkConfDiffSuppress(t *testing.T) {
 	assert.True(t, scs.DiffSuppressFunc("new_cluster.0.spark_conf.%", "1", "0", nil))
 	assert.False(t, scs.DiffSuppressFunc("new_cluster.0.spark_conf.%", "1", "1", nil))
 }
+
+func runOnApplyFixtures(resultState string) []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/jobs/run-now",
+			ExpectedRequest: RunParameters{
+				JobID: 789,
+			},
+			Response: JobRun{
+				RunID: 890,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/runs/get?run_id=890",
+			Response: JobRun{
+				RunID: 890,
+				State: RunState{
+					LifeCycleState: "RUNNING",
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/jobs/runs/get?run_id=890",
+			Response: JobRun{
+				RunID:      890,
+				RunPageURL: "https://example.com/#job/789/run/890",
+				State: RunState{
+					LifeCycleState: "TERMINATED",
+					ResultState:    resultState,
+					StateMessage:   "Done",
+				},
+			},
+		},
+	}
+}
+
+var runOnApplyJobFixtures = []qa.HTTPFixture{
+	{
+		Method:   "POST",
+		Resource: "/api/2.0/jobs/create",
+		ExpectedRequest: JobSettings{
+			ExistingClusterID: "abc",
+			SparkJarTask: &SparkJarTask{
+				MainClassName: "com.labs.Migration",
+			},
+			Name:              "Migration",
+			MaxConcurrentRuns: 1,
+		},
+		Response: Job{
+			JobID: 789,
+		},
+	},
+	{
+		Method:       "GET",
+		Resource:     "/api/2.0/jobs/get?job_id=789",
+		ReuseRequest: true,
+		Response: Job{
+			JobID: 789,
+			Settings: &JobSettings{
+				ExistingClusterID: "abc",
+				SparkJarTask: &SparkJarTask{
+					MainClassName: "com.labs.Migration",
+				},
+				Name:              "Migration",
+				MaxConcurrentRuns: 1,
+			},
+		},
+	},
+}
+
+var runOnApplyJobHCL = `
+existing_cluster_id = "abc"
+name = "Migration"
+spark_jar_task {
+	main_class_name = "com.labs.Migration"
+}
+`
+
+func TestResourceJobCreate_RunOnApply(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: append(runOnApplyJobFixtures, runOnApplyFixtures("SUCCESS")...),
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL:      runOnApplyJobHCL + `run_on_apply {}`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                "789",
+		"last_run_id":       890,
+		"last_run_state":    "SUCCESS",
+		"last_run_page_url": "https://example.com/#job/789/run/890",
+	})
+}
+
+func TestResourceJobCreate_RunOnApplyFailed(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: append(runOnApplyJobFixtures, runOnApplyFixtures("FAILED")...),
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL:      runOnApplyJobHCL + `run_on_apply {}`,
+	}.Apply(t)
+	assert.EqualError(t, err, "run 890 of job 789 finished with FAILED: Done https://example.com/#job/789/run/890")
+	// the job is created, so Terraform taints it, and the failed run is kept in the state
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, 890, d.Get("last_run_id"))
+	assert.Equal(t, "FAILED", d.Get("last_run_state"))
+}
+
+func TestResourceJobCreate_RunOnApplyConflict(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: runOnApplyJobHCL + `run_on_apply {}
+		control_run_state = true`,
+	}.ExpectError(t, "invalid config supplied. "+
+		"[control_run_state] Conflicting configuration arguments. "+
+		"[run_on_apply] Conflicting configuration arguments")
+}
+
+func runOnApplyState(onChange ...string) map[string]string {
+	state := map[string]string{
+		"name":                             "Migration",
+		"existing_cluster_id":              "abc",
+		"max_concurrent_runs":              "1",
+		"spark_jar_task.#":                 "1",
+		"spark_jar_task.0.main_class_name": "com.labs.Migration",
+		"run_on_apply.#":                   "1",
+		"run_on_apply.0.on_change.#":       fmt.Sprint(len(onChange)),
+		"last_run_id":                      "123",
+		"last_run_state":                   "SUCCESS",
+	}
+	for _, attr := range onChange {
+		state[fmt.Sprintf("run_on_apply.0.on_change.%d", schema.HashString(attr))] = attr
+	}
+	return state
+}
+
+func TestResourceJobUpdate_RunOnApply(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: append([]qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/reset",
+				ExpectedRequest: UpdateJobRequest{
+					JobID: 789,
+					NewSettings: &JobSettings{
+						ExistingClusterID: "abc",
+						SparkJarTask: &SparkJarTask{
+							MainClassName: "com.labs.Migration",
+						},
+						Name:              "Migration",
+						MaxConcurrentRuns: 1,
+						MaxRetries:        1,
+					},
+				},
+			},
+			runOnApplyJobFixtures[1],
+		}, runOnApplyFixtures("SUCCESS")...),
+		ID:            "789",
+		Update:        true,
+		Resource:      ResourceJob(),
+		InstanceState: runOnApplyState("spark_jar_task", "max_retries"),
+		HCL: runOnApplyJobHCL + `
+		max_retries = 1
+		run_on_apply {
+			on_change = ["spark_jar_task", "max_retries"]
+		}`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"last_run_id":    890,
+		"last_run_state": "SUCCESS",
+	})
+}
+
+func TestResourceJobUpdate_RunOnApplyFailedRunsAgain(t *testing.T) {
+	resetFixture := qa.HTTPFixture{
+		Method:   "POST",
+		Resource: "/api/2.0/jobs/reset",
+		ExpectedRequest: UpdateJobRequest{
+			JobID: 789,
+			NewSettings: &JobSettings{
+				ExistingClusterID: "abc",
+				SparkJarTask: &SparkJarTask{
+					MainClassName: "com.labs.Migration",
+				},
+				Name:              "Migration",
+				MaxConcurrentRuns: 1,
+				MaxRetries:        1,
+			},
+		},
+	}
+	hcl := runOnApplyJobHCL + `
+	max_retries = 1
+	run_on_apply {
+		on_change = ["max_retries"]
+	}`
+	d, err := qa.ResourceFixture{
+		Fixtures:      append([]qa.HTTPFixture{resetFixture}, runOnApplyFixtures("FAILED")...),
+		ID:            "789",
+		Update:        true,
+		Resource:      ResourceJob(),
+		InstanceState: runOnApplyState("max_retries"),
+		HCL:           hcl,
+	}.Apply(t)
+	assert.EqualError(t, err, "run 890 of job 789 finished with FAILED: Done https://example.com/#job/789/run/890")
+	state := d.State().Attributes
+	assert.Equal(t, "1", state["max_retries"], "updated settings must be in the state")
+	assert.Equal(t, "890", state["last_run_id"])
+	assert.Equal(t, "FAILED", state["last_run_state"])
+	assert.Equal(t, "https://example.com/#job/789/run/890", state["last_run_page_url"])
+
+	// the job isn't changed, but the failed run is repeated
+	qa.ResourceFixture{
+		Fixtures: append([]qa.HTTPFixture{
+			resetFixture,
+			runOnApplyJobFixtures[1],
+		}, runOnApplyFixtures("SUCCESS")...),
+		ID:            "789",
+		Update:        true,
+		Resource:      ResourceJob(),
+		InstanceState: state,
+		HCL:           hcl,
+	}.ApplyAndExpectData(t, map[string]any{
+		"last_run_id":    890,
+		"last_run_state": "SUCCESS",
+	})
+}
+
+func TestResourceJobCreate_RunOnApplyUnknownOnChange(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: runOnApplyJobHCL + `
+		run_on_apply {
+			on_change = ["tasks"]
+		}`,
+	}.Apply(t)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "[run_on_apply.#.on_change] expected run_on_apply.0.on_change.0 to be one of")
+	assert.True(t, strings.HasSuffix(err.Error(), "got tasks"), err.Error())
+}
+
+func TestResourceJobUpdate_RunOnApplyNotTriggered(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/reset",
+				ExpectedRequest: UpdateJobRequest{
+					JobID: 789,
+					NewSettings: &JobSettings{
+						ExistingClusterID: "abc",
+						SparkJarTask: &SparkJarTask{
+							MainClassName: "com.labs.Migration",
+						},
+						Name:              "Migration",
+						MaxConcurrentRuns: 1,
+						MaxRetries:        1,
+					},
+				},
+			},
+			runOnApplyJobFixtures[1],
+		},
+		ID:            "789",
+		Update:        true,
+		Resource:      ResourceJob(),
+		InstanceState: runOnApplyState("spark_jar_task"),
+		HCL: runOnApplyJobHCL + `
+		max_retries = 1
+		run_on_apply {
+			on_change = ["spark_jar_task"]
+		}`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"last_run_id":    123,
+		"last_run_state": "SUCCESS",
+	})
+}
@@ -0,0 +1,142 @@
+package jobs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+)
+
+// validateTasks checks the graph of tasks, so that typos in task keys are reported during plan
+// and not after the other resources are applied. Empty keys are skipped, because they are not
+// known until apply, when they are referring to other resources.
+func (js *JobSettings) validateTasks() error {
+	if len(js.Tasks) == 0 {
+		return nil
+	}
+	jobClusters := map[string]bool{}
+	for _, jc := range js.JobClusters {
+		jobClusters[jc.JobClusterKey] = true
+	}
+	computes := map[string]bool{}
+	for _, c := range js.Compute {
+		computes[c.ComputeKey] = true
+	}
+	tasks := map[string]*JobTaskSettings{}
+	var keys []string
+	for i := range js.Tasks {
+		task := &js.Tasks[i]
+		if task.TaskKey == "" {
+			continue
+		}
+		if _, ok := tasks[task.TaskKey]; ok {
+			return fmt.Errorf("task_key %s is used by more than one task", task.TaskKey)
+		}
+		tasks[task.TaskKey] = task
+		keys = append(keys, task.TaskKey)
+	}
+	for _, task := range js.Tasks {
+		if err := task.validateCompute(jobClusters, computes); err != nil {
+			return err
+		}
+		if err := task.validateRunIf(); err != nil {
+			return err
+		}
+		for _, dep := range task.DependsOn {
+			if dep.TaskKey == "" {
+				continue
+			}
+			if _, ok := tasks[dep.TaskKey]; !ok {
+				return fmt.Errorf("task %s depends on unknown task %s", task.TaskKey, dep.TaskKey)
+			}
+		}
+	}
+	return findCycle(tasks, keys)
+}
+
+func (task *JobTaskSettings) validateCompute(jobClusters, computes map[string]bool) error {
+	// keys of job clusters or computes might be unknown until apply
+	if task.JobClusterKey != "" && !jobClusters[task.JobClusterKey] && !jobClusters[""] {
+		return fmt.Errorf("task %s refers to unknown job_cluster_key %s", task.TaskKey, task.JobClusterKey)
+	}
+	if task.ComputeKey != "" && !computes[task.ComputeKey] && !computes[""] {
+		return fmt.Errorf("task %s refers to unknown compute_key %s", task.TaskKey, task.ComputeKey)
+	}
+	var clusterTypes []string
+	if task.ExistingClusterID != "" {
+		clusterTypes = append(clusterTypes, "existing_cluster_id")
+	}
+	if task.NewCluster != nil {
+		clusterTypes = append(clusterTypes, "new_cluster")
+	}
+	if task.JobClusterKey != "" {
+		clusterTypes = append(clusterTypes, "job_cluster_key")
+	}
+	if task.ComputeKey != "" {
+		clusterTypes = append(clusterTypes, "compute_key")
+	}
+	if len(clusterTypes) > 1 {
+		return fmt.Errorf("task %s must have only one of %s", task.TaskKey, strings.Join(clusterTypes, ", "))
+	}
+	return nil
+}
+
+// validateRunIf checks that run_if is specified only for tasks with dependencies,
+// because all conditions other than ALL_SUCCESS are about outcomes of upstream tasks
+func (task *JobTaskSettings) validateRunIf() error {
+	if task.RunIf == "" {
+		return nil
+	}
+	var runIf jobs.RunIf
+	if err := runIf.Set(task.RunIf); err != nil {
+		return fmt.Errorf("task %s has invalid run_if: %w", task.TaskKey, err)
+	}
+	if len(task.DependsOn) == 0 && runIf != jobs.RunIfAllSuccess {
+		return fmt.Errorf("task %s has run_if = %s, but doesn't depend on other tasks", task.TaskKey, task.RunIf)
+	}
+	return nil
+}
+
+// findCycle returns an error with the first circular dependency, found by depth-first search
+// starting from tasks in the given order, so that the error is stable
+func findCycle(tasks map[string]*JobTaskSettings, keys []string) error {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	var path []string
+	var visit func(key string) error
+	visit = func(key string) error {
+		switch state[key] {
+		case visited:
+			return nil
+		case visiting:
+			for i, k := range path {
+				if k == key {
+					return fmt.Errorf("tasks have circular dependency: %s",
+						strings.Join(append(path[i:], key), " -> "))
+				}
+			}
+		}
+		state[key] = visiting
+		path = append(path, key)
+		for _, dep := range tasks[key].DependsOn {
+			if _, ok := tasks[dep.TaskKey]; !ok {
+				continue
+			}
+			if err := visit(dep.TaskKey); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[key] = visited
+		return nil
+	}
+	for _, key := range keys {
+		if err := visit(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
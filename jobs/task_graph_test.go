@@ -0,0 +1,93 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/databricks/terraform-provider-databricks/clusters"
+	"github.com/stretchr/testify/assert"
+)
+
+func dependsOn(keys ...string) (deps []jobs.TaskDependency) {
+	for _, key := range keys {
+		deps = append(deps, jobs.TaskDependency{TaskKey: key})
+	}
+	return deps
+}
+
+func TestValidateTasks(t *testing.T) {
+	js := JobSettings{
+		JobClusters: []JobCluster{{JobClusterKey: "shared"}},
+		Compute:     []JobCompute{{ComputeKey: "serverless", ComputeSpec: &compute.ComputeSpec{}}},
+		Tasks: []JobTaskSettings{
+			{TaskKey: "a", JobClusterKey: "shared"},
+			{TaskKey: "b", DependsOn: dependsOn("a"), ComputeKey: "serverless"},
+			{TaskKey: "c", DependsOn: dependsOn("a", "b"), RunIf: "ALL_DONE", ExistingClusterID: "abc"},
+			{TaskKey: "d", DependsOn: dependsOn("c", ""), RunIf: "ALL_SUCCESS"},
+			{TaskKey: "e", RunIf: "ALL_SUCCESS"},
+		},
+	}
+	assert.NoError(t, js.validateTasks())
+	assert.NoError(t, (&JobSettings{}).validateTasks())
+}
+
+func TestValidateTasks_Errors(t *testing.T) {
+	for expected, tasks := range map[string][]JobTaskSettings{
+		"task_key a is used by more than one task": {
+			{TaskKey: "a"},
+			{TaskKey: "a"},
+		},
+		"task b depends on unknown task c": {
+			{TaskKey: "a"},
+			{TaskKey: "b", DependsOn: dependsOn("a", "c")},
+		},
+		"tasks have circular dependency: a -> a": {
+			{TaskKey: "a", DependsOn: dependsOn("a")},
+		},
+		"tasks have circular dependency: b -> c -> d -> b": {
+			{TaskKey: "a"},
+			{TaskKey: "b", DependsOn: dependsOn("a", "c")},
+			{TaskKey: "c", DependsOn: dependsOn("d")},
+			{TaskKey: "d", DependsOn: dependsOn("b")},
+		},
+		"task a refers to unknown job_cluster_key typo": {
+			{TaskKey: "a", JobClusterKey: "typo"},
+		},
+		"task a refers to unknown compute_key typo": {
+			{TaskKey: "a", ComputeKey: "typo"},
+		},
+		"task a must have only one of existing_cluster_id, job_cluster_key": {
+			{TaskKey: "a", ExistingClusterID: "abc", JobClusterKey: "shared"},
+		},
+		"task a must have only one of new_cluster, compute_key": {
+			{TaskKey: "a", NewCluster: &clusters.Cluster{}, ComputeKey: "serverless"},
+		},
+		"task a has run_if = ALL_FAILED, but doesn't depend on other tasks": {
+			{TaskKey: "a", RunIf: "ALL_FAILED"},
+		},
+		"task b has invalid run_if: value \"SOMETIMES\" is not one of \"ALL_DONE\", \"ALL_FAILED\", \"ALL_SUCCESS\", \"AT_LEAST_ONE_FAILED\", \"AT_LEAST_ONE_SUCCESS\", \"NONE_FAILED\"": {
+			{TaskKey: "a"},
+			{TaskKey: "b", DependsOn: dependsOn("a"), RunIf: "SOMETIMES"},
+		},
+	} {
+		js := JobSettings{
+			JobClusters: []JobCluster{{JobClusterKey: "shared"}},
+			Compute:     []JobCompute{{ComputeKey: "serverless"}},
+			Tasks:       tasks,
+		}
+		assert.EqualError(t, js.validateTasks(), expected)
+	}
+}
+
+func TestValidateTasks_UnknownKeys(t *testing.T) {
+	js := JobSettings{
+		JobClusters: []JobCluster{{JobClusterKey: ""}},
+		Compute:     []JobCompute{{ComputeKey: ""}},
+		Tasks: []JobTaskSettings{
+			{TaskKey: "a", JobClusterKey: "shared"},
+			{TaskKey: "b", ComputeKey: "serverless"},
+		},
+	}
+	assert.NoError(t, js.validateTasks())
+}
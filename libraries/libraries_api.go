@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/compute"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
@@ -52,32 +53,60 @@ type Wait struct {
 	Timeout   time.Duration
 	IsRunning bool
 	IsRefresh bool
+	// Retries is the number of times libraries that failed to install are installed again,
+	// as Maven & PyPI resolution failures are often transient. Every retry restarts the cluster.
+	Retries int
+	// Optional is the set of libraries (see Library.String) that are allowed to fail to install
+	Optional map[string]bool
+	// Libraries limits the wait to the given set of libraries (see Library.String), so that failures
+	// of other libraries on the same cluster are ignored. All libraries are waited for, if it's empty.
+	Libraries map[string]bool
 }
 
-func (a LibrariesAPI) UpdateLibraries(clusterID string, add, remove ClusterLibraryList, timeout time.Duration) error {
+func (a LibrariesAPI) UpdateLibraries(add, remove ClusterLibraryList, wait Wait) (*ClusterLibraryStatuses, error) {
 	if len(remove.Libraries) > 0 {
 		err := a.Uninstall(remove)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 	if len(add.Libraries) > 0 {
 		err := a.Install(add)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
-	_, err := a.WaitForLibrariesInstalled(Wait{
-		ClusterID: clusterID,
-		Timeout:   timeout,
-		IsRunning: true,
-		IsRefresh: false,
+	wait.IsRunning = true
+	wait.IsRefresh = false
+	return a.WaitForLibrariesInstalled(wait)
+}
+
+// reinstall installs libraries, that failed to install, once again. Failed library keeps its status
+// until it's uninstalled, and libraries are uninstalled only when the cluster is restarted, so the cluster
+// is restarted between uninstalling and installing libraries again.
+// See https://docs.databricks.com/api/workspace/libraries/uninstall
+func (a LibrariesAPI) reinstall(failed ClusterLibraryList) error {
+	if err := a.Uninstall(failed); err != nil {
+		return err
+	}
+	w, err := a.client.WorkspaceClient()
+	if err != nil {
+		return err
+	}
+	_, err = w.Clusters.RestartAndWait(a.context, compute.RestartCluster{
+		ClusterId: failed.ClusterID,
 	})
-	return err
+	if err != nil {
+		return fmt.Errorf("cannot restart cluster %s to reinstall libraries: %w", failed.ClusterID, err)
+	}
+	return a.Install(failed)
 }
 
-// clusterID string, timeout time.Duration, isActive bool, refresh bool
+// WaitForLibrariesInstalled waits until all libraries on a running cluster are either installed or failed.
+// If installation of some libraries has failed, the result is returned together with the error,
+// so that callers could report the status of every library.
 func (a LibrariesAPI) WaitForLibrariesInstalled(wait Wait) (result *ClusterLibraryStatuses, err error) {
+	attempts := map[string]int{}
 	err = resource.RetryContext(a.context, wait.Timeout, func() *resource.RetryError {
 		libsClusterStatus, err := a.ClusterStatus(wait.ClusterID)
 		if err != nil {
@@ -91,20 +120,33 @@ func (a LibrariesAPI) WaitForLibrariesInstalled(wait Wait) (result *ClusterLibra
 			}
 			return resource.NonRetryableError(apiErr)
 		}
+		libsClusterStatus = libsClusterStatus.only(wait.Libraries)
 		if !wait.IsRunning {
 			log.Printf("[INFO] Cluster %s is currently not running, so just returning list of %d libraries",
 				wait.ClusterID, len(libsClusterStatus.LibraryStatuses))
 			result = &libsClusterStatus
 			return nil
 		}
-		retry, err := libsClusterStatus.IsRetryNeeded(wait.IsRefresh)
+		if !wait.IsRefresh {
+			reinstall := libsClusterStatus.failedToRetry(attempts, wait.Retries)
+			if len(reinstall.Libraries) > 0 {
+				reinstall.ClusterID = wait.ClusterID
+				log.Printf("[WARN] Retrying installation of %s", reinstall.String())
+				if err = a.reinstall(reinstall); err != nil {
+					return resource.NonRetryableError(err)
+				}
+				return resource.RetryableError(fmt.Errorf("retrying installation of %d failed libraries",
+					len(reinstall.Libraries)))
+			}
+		}
+		retry, err := libsClusterStatus.withoutOptionalFailures(wait.Optional).IsRetryNeeded(wait.IsRefresh)
 		if retry {
 			return resource.RetryableError(err)
 		}
+		result = &libsClusterStatus
 		if err != nil {
 			return resource.NonRetryableError(err)
 		}
-		result = &libsClusterStatus
 		return nil
 	})
 	if err != nil {
@@ -116,9 +158,10 @@ func (a LibrariesAPI) WaitForLibrariesInstalled(wait Wait) (result *ClusterLibra
 			ClusterID: wait.ClusterID,
 			Libraries: []Library{},
 		}
-		// cleanup libraries that failed to install
+		// cleanup libraries that failed to install, unless they are optional
 		for _, v := range result.LibraryStatuses {
-			if v.Status == "FAILED" {
+			if v.Status == "FAILED" && !wait.Optional[v.Library.String()] {
+				result.Failed = append(result.Failed, v)
 				log.Printf("[WARN] Removing failed library %s from %s", v.Library, wait.ClusterID)
 				cleanup.Libraries = append(cleanup.Libraries, *v.Library)
 				continue
@@ -304,6 +347,57 @@ type LibraryStatus struct {
 type ClusterLibraryStatuses struct {
 	ClusterID       string          `json:"cluster_id,omitempty"`
 	LibraryStatuses []LibraryStatus `json:"library_statuses,omitempty"`
+	// Failed contains libraries that failed to install and were removed from the cluster
+	Failed []LibraryStatus `json:"-"`
+}
+
+// failedToRetry returns libraries that failed to install and weren't retried for the given number of times yet
+func (cls ClusterLibraryStatuses) failedToRetry(attempts map[string]int, retries int) ClusterLibraryList {
+	cll := ClusterLibraryList{ClusterID: cls.ClusterID}
+	for _, lib := range cls.LibraryStatuses {
+		if lib.IsGlobal || lib.Status != "FAILED" {
+			continue
+		}
+		key := lib.Library.String()
+		if attempts[key] >= retries {
+			continue
+		}
+		attempts[key]++
+		cll.Libraries = append(cll.Libraries, *lib.Library)
+	}
+	cll.Sort()
+	return cll
+}
+
+// only returns statuses of the given libraries, or all statuses, if no libraries are given
+func (cls ClusterLibraryStatuses) only(libraries map[string]bool) ClusterLibraryStatuses {
+	if len(libraries) == 0 {
+		return cls
+	}
+	filtered := ClusterLibraryStatuses{ClusterID: cls.ClusterID}
+	for _, lib := range cls.LibraryStatuses {
+		if lib.Library != nil && libraries[lib.Library.String()] {
+			filtered.LibraryStatuses = append(filtered.LibraryStatuses, lib)
+		}
+	}
+	return filtered
+}
+
+// withoutOptionalFailures returns statuses without optional libraries that failed to install
+func (cls ClusterLibraryStatuses) withoutOptionalFailures(optional map[string]bool) ClusterLibraryStatuses {
+	if len(optional) == 0 {
+		return cls
+	}
+	filtered := ClusterLibraryStatuses{ClusterID: cls.ClusterID}
+	for _, lib := range cls.LibraryStatuses {
+		if lib.Status == "FAILED" && optional[lib.Library.String()] {
+			log.Printf("[WARN] Optional library %s failed to install on %s: %s",
+				lib.Library, cls.ClusterID, strings.Join(lib.Messages, ", "))
+			continue
+		}
+		filtered.LibraryStatuses = append(filtered.LibraryStatuses, lib)
+	}
+	return filtered
 }
 
 // ToLibraryList convert to envity for convenient comparison
@@ -3,6 +3,7 @@ package libraries
 import (
 	"context"
 	"errors"
+	"github.com/databricks/databricks-sdk-go/service/compute"
 	"testing"
 	"time"
 
@@ -94,41 +95,62 @@ func TestWaitForLibrariesInstalled(t *testing.T) {
 	}, func(ctx context.Context, client *common.DatabricksClient) {
 		libs := NewLibrariesAPI(ctx, client)
 		_, err := libs.WaitForLibrariesInstalled(Wait{
-			"missing", 50 * time.Millisecond, true, false,
+			ClusterID: "missing",
+			Timeout:   50 * time.Millisecond,
+			IsRunning: true,
+			IsRefresh: false,
 		})
 		assert.EqualError(t, err, "missing")
 
 		_, err = libs.WaitForLibrariesInstalled(Wait{
-			"error", 50 * time.Millisecond, true, false,
+			ClusterID: "error",
+			Timeout:   50 * time.Millisecond,
+			IsRunning: true,
+			IsRefresh: false,
 		})
 		assert.EqualError(t, err, "internal error")
 
 		// cluster is not running
 		_, err = libs.WaitForLibrariesInstalled(Wait{
-			"still-installing", 50 * time.Millisecond, false, false,
+			ClusterID: "still-installing",
+			Timeout:   50 * time.Millisecond,
+			IsRunning: false,
+			IsRefresh: false,
 		})
 		assert.NoError(t, err)
 
 		// cluster is running
 		_, err = libs.WaitForLibrariesInstalled(Wait{
-			"still-installing", 50 * time.Millisecond, true, false,
+			ClusterID: "still-installing",
+			Timeout:   50 * time.Millisecond,
+			IsRunning: true,
+			IsRefresh: false,
 		})
 		assert.EqualError(t, err, "0 libraries are ready, but there are still 1 pending")
 
 		_, err = libs.WaitForLibrariesInstalled(Wait{
-			"failed-wheel", 50 * time.Millisecond, true, false,
+			ClusterID: "failed-wheel",
+			Timeout:   50 * time.Millisecond,
+			IsRunning: true,
+			IsRefresh: false,
 		})
 		assert.EqualError(t, err, "whl:b.whl failed: does not compute")
 
 		// uninstall b.whl and continue executing
 		_, err = libs.WaitForLibrariesInstalled(Wait{
-			"failed-wheel", 50 * time.Millisecond, true, true,
+			ClusterID: "failed-wheel",
+			Timeout:   50 * time.Millisecond,
+			IsRunning: true,
+			IsRefresh: true,
 		})
 		assert.NoError(t, err, "library should have been uninstalled and work proceeded")
 
 		// Cluster not available or doesn't exist
 		_, err = libs.WaitForLibrariesInstalled(Wait{
-			"1005-abcd", 50 * time.Millisecond, false, false,
+			ClusterID: "1005-abcd",
+			Timeout:   50 * time.Millisecond,
+			IsRunning: false,
+			IsRefresh: false,
 		})
 
 		var ae *apierr.APIError
@@ -138,6 +160,247 @@ func TestWaitForLibrariesInstalled(t *testing.T) {
 	})
 }
 
+func TestWaitForLibrariesInstalled_Retries(t *testing.T) {
+	failedMaven := LibraryStatus{
+		Status:   "FAILED",
+		Messages: []string{"cannot resolve"},
+		Library: &Library{
+			Maven: &Maven{
+				Coordinates: "a:b:c",
+			},
+		},
+	}
+	installedJar := LibraryStatus{
+		Status: "INSTALLED",
+		Library: &Library{
+			Jar: "a.jar",
+		},
+	}
+	mavenOnly := ClusterLibraryList{
+		ClusterID: "abc",
+		Libraries: []Library{
+			{
+				Maven: &Maven{
+					Coordinates: "a:b:c",
+				},
+			},
+		},
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+			Response: ClusterLibraryStatuses{
+				ClusterID:       "abc",
+				LibraryStatuses: []LibraryStatus{failedMaven, installedJar},
+			},
+		},
+		// failed library is uninstalled, and it's removed only on restart
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/libraries/uninstall",
+			ExpectedRequest: mavenOnly,
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/restart",
+			ExpectedRequest: compute.RestartCluster{
+				ClusterId: "abc",
+			},
+		},
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+			ReuseRequest: true,
+			Response: compute.ClusterDetails{
+				ClusterId: "abc",
+				State:     compute.StateRunning,
+			},
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/libraries/install",
+			ExpectedRequest: mavenOnly,
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+			Response: ClusterLibraryStatuses{
+				ClusterID: "abc",
+				LibraryStatuses: []LibraryStatus{
+					{
+						Status:  "INSTALLED",
+						Library: failedMaven.Library,
+					},
+					installedJar,
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		result, err := NewLibrariesAPI(ctx, client).WaitForLibrariesInstalled(Wait{
+			ClusterID: "abc",
+			Timeout:   10 * time.Second,
+			IsRunning: true,
+			Retries:   1,
+		})
+		require.NoError(t, err)
+		assert.Len(t, result.LibraryStatuses, 2)
+		assert.Len(t, result.Failed, 0)
+	})
+}
+
+func TestWaitForLibrariesInstalled_RetriesExhausted(t *testing.T) {
+	failed := ClusterLibraryStatuses{
+		ClusterID: "abc",
+		LibraryStatuses: []LibraryStatus{
+			{
+				Status:   "FAILED",
+				Messages: []string{"cannot resolve"},
+				Library: &Library{
+					Maven: &Maven{
+						Coordinates: "a:b:c",
+					},
+				},
+			},
+		},
+	}
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+			ReuseRequest: true,
+			Response:     failed,
+		},
+		{
+			Method:       "POST",
+			Resource:     "/api/2.0/libraries/uninstall",
+			ReuseRequest: true,
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/restart",
+		},
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+			ReuseRequest: true,
+			Response: compute.ClusterDetails{
+				ClusterId: "abc",
+				State:     compute.StateRunning,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/libraries/install",
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		result, err := NewLibrariesAPI(ctx, client).WaitForLibrariesInstalled(Wait{
+			ClusterID: "abc",
+			Timeout:   10 * time.Second,
+			IsRunning: true,
+			Retries:   1,
+		})
+		assert.EqualError(t, err, "mvn:a:b:c failed: cannot resolve")
+		require.NotNil(t, result)
+		assert.Equal(t, "FAILED", result.LibraryStatuses[0].Status)
+	})
+}
+
+func TestWaitForLibrariesInstalled_Optional(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+			ReuseRequest: true,
+			Response: ClusterLibraryStatuses{
+				ClusterID: "abc",
+				LibraryStatuses: []LibraryStatus{
+					{
+						Status:   "FAILED",
+						Messages: []string{"cannot resolve"},
+						Library: &Library{
+							Pypi: &PyPi{
+								Package: "optional-package",
+							},
+						},
+					},
+					{
+						Status:   "FAILED",
+						Messages: []string{"does not compute"},
+						Library: &Library{
+							Whl: "b.whl",
+						},
+					},
+					{
+						Status: "INSTALLED",
+						Library: &Library{
+							Jar: "a.jar",
+						},
+					},
+				},
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/libraries/uninstall",
+			ExpectedRequest: ClusterLibraryList{
+				ClusterID: "abc",
+				Libraries: []Library{
+					{
+						Whl: "b.whl",
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		libs := NewLibrariesAPI(ctx, client)
+		_, err := libs.WaitForLibrariesInstalled(Wait{
+			ClusterID: "abc",
+			Timeout:   1 * time.Second,
+			IsRunning: true,
+			Optional:  map[string]bool{"pypi:optional-package": true},
+		})
+		assert.EqualError(t, err, "whl:b.whl failed: does not compute")
+
+		result, err := libs.WaitForLibrariesInstalled(Wait{
+			ClusterID: "abc",
+			Timeout:   1 * time.Second,
+			IsRunning: true,
+			Optional: map[string]bool{
+				"pypi:optional-package": true,
+				"jar:a.jar":             true,
+			},
+			IsRefresh: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pypi:optional-package", "jar:a.jar"}, []string{
+			result.LibraryStatuses[0].Library.String(),
+			result.LibraryStatuses[1].Library.String(),
+		})
+		require.Len(t, result.Failed, 1)
+		assert.Equal(t, "whl:b.whl", result.Failed[0].Library.String())
+	})
+}
+
+func TestClusterLibraryStatuses_FailedToRetry(t *testing.T) {
+	cls := ClusterLibraryStatuses{
+		ClusterID: "abc",
+		LibraryStatuses: []LibraryStatus{
+			{Status: "FAILED", Library: &Library{Whl: "b.whl"}},
+			{Status: "FAILED", Library: &Library{Jar: "a.jar"}},
+			{Status: "FAILED", Library: &Library{Jar: "global.jar"}, IsGlobal: true},
+			{Status: "INSTALLED", Library: &Library{Jar: "c.jar"}},
+		},
+	}
+	attempts := map[string]int{}
+	for i := 0; i < 2; i++ {
+		reinstall := cls.failedToRetry(attempts, 2)
+		assert.Equal(t, "abc/jar:a.jar,whl:b.whl", reinstall.String())
+	}
+	assert.Len(t, cls.failedToRetry(attempts, 2).Libraries, 0)
+	assert.Len(t, cls.failedToRetry(map[string]int{}, 0).Libraries, 0)
+}
+
 func TestClusterLibraryStatuses_UpdateLibraries(t *testing.T) {
 	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
 		{
@@ -169,7 +432,7 @@ func TestClusterLibraryStatuses_UpdateLibraries(t *testing.T) {
 		},
 	}, func(ctx context.Context, client *common.DatabricksClient) {
 		libsAPI := NewLibrariesAPI(ctx, client)
-		err := libsAPI.UpdateLibraries("abc", ClusterLibraryList{
+		_, err := libsAPI.UpdateLibraries(ClusterLibraryList{
 			Libraries: []Library{
 				{
 					Jar: "add.jar",
@@ -181,7 +444,10 @@ func TestClusterLibraryStatuses_UpdateLibraries(t *testing.T) {
 					Jar: "remove.jar",
 				},
 			},
-		}, 1*time.Second)
+		}, Wait{
+			ClusterID: "abc",
+			Timeout:   1 * time.Second,
+		})
 		assert.NoError(t, err)
 	})
 }
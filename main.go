@@ -5,8 +5,10 @@ import (
 	"log"
 	"os"
 
+	"github.com/databricks/terraform-provider-databricks/commands"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/exporter"
+	"github.com/databricks/terraform-provider-databricks/pools"
 	"github.com/databricks/terraform-provider-databricks/provider"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 )
@@ -18,7 +20,16 @@ func main() {
 		return
 	}
 	if len(os.Args) > 1 && os.Args[1] == "exporter" {
-		if err := exporter.Run(os.Args...); err != nil {
+		err := exporter.Run(os.Args...)
+		commands.DestroyIdleContexts()
+		if err != nil {
+			log.Printf("[ERROR] %s", err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "reconcile-pool-schedules" {
+		if err := pools.RunScheduleReconciler(os.Args...); err != nil {
 			log.Printf("[ERROR] %s", err.Error())
 			os.Exit(1)
 		}
@@ -40,4 +51,7 @@ https://registry.terraform.io/providers/databricks/databricks/latest/docs
 		ProviderAddr: "registry.terraform.io/databricks/databricks",
 		Debug:        debug,
 	})
+	// execution contexts are shared between resources, so idle ones are destroyed on exit. It's best-effort,
+	// as Terraform might kill the provider before, so contexts are also evicted by idle timeout.
+	commands.DestroyIdleContexts()
 }
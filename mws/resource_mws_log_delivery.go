@@ -48,6 +48,15 @@ func (a LogDeliveryAPI) Read(accountID, configID string) (LogDeliveryConfigurati
 	return ld.LogDeliveryConfiguration, err
 }
 
+// List returns all log delivery configurations of an account
+func (a LogDeliveryAPI) List(accountID string) ([]LogDeliveryConfiguration, error) {
+	var ldl struct {
+		LogDeliveryConfigurations []LogDeliveryConfiguration `json:"log_delivery_configurations,omitempty"`
+	}
+	err := a.client.Get(a.context, fmt.Sprintf("/accounts/%s/log-delivery", accountID), nil, &ldl)
+	return ldl.LogDeliveryConfigurations, err
+}
+
 // Create new log delivery configuration
 func (a LogDeliveryAPI) Create(ldc LogDeliveryConfiguration) (string, error) {
 	var ld LogDelivery
@@ -1,12 +1,14 @@
 package mws
 
 import (
+	"context"
 	"testing"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/terraform-provider-databricks/qa"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceLogDeliveryCreate(t *testing.T) {
@@ -383,3 +385,28 @@ func TestResourceLogDeliveryDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc|nid", d.Id())
 }
+
+func TestResourceLogDeliveryList(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc/log-delivery",
+			Response: map[string]any{
+				"log_delivery_configurations": []LogDeliveryConfiguration{
+					{
+						AccountID: "abc",
+						ConfigID:  "nid",
+						LogType:   "AUDIT_LOGS",
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	l, err := NewLogDeliveryAPI(context.Background(), client).List("abc")
+	require.NoError(t, err)
+	require.Len(t, l, 1)
+	assert.Equal(t, "nid", l[0].ConfigID)
+}
@@ -0,0 +1,146 @@
+package policies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/clusters"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type policyConstraint struct {
+	Path         string   `json:"path"`
+	Type         string   `json:"type"`
+	Value        string   `json:"value,omitempty"`
+	Values       []string `json:"values,omitempty"`
+	Pattern      string   `json:"pattern,omitempty"`
+	MinValue     float64  `json:"min_value,omitempty"`
+	MaxValue     float64  `json:"max_value,omitempty"`
+	DefaultValue string   `json:"default_value,omitempty"`
+	IsOptional   bool     `json:"is_optional,omitempty"`
+	Hidden       bool     `json:"hidden,omitempty"`
+}
+
+// mergePolicyDefinition applies overrides on top of the policy family definition. Rules from overrides
+// replace rules of the family for the same attribute path
+func mergePolicyDefinition(familyDefinition, overrides string) (string, error) {
+	merged := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(familyDefinition), &merged); err != nil {
+		return "", fmt.Errorf("can't parse policy family definition: %w", err)
+	}
+	if overrides != "" {
+		var rules map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(overrides), &rules); err != nil {
+			return "", fmt.Errorf("can't parse policy_family_definition_overrides: %w", err)
+		}
+		for path, rule := range rules {
+			merged[path] = rule
+		}
+	}
+	raw, err := json.Marshal(merged)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+func policyValueString(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// policyConstraints decodes the policy definition into the list of constraints, sorted by path,
+// and the map of values that are set from the policy if attributes aren't specified
+func policyConstraints(definition string) ([]policyConstraint, map[string]string, error) {
+	pd, err := clusters.ParsePolicyDefinition(definition)
+	if err != nil {
+		return nil, nil, err
+	}
+	constraints := []policyConstraint{}
+	defaults := map[string]string{}
+	for path, rule := range pd {
+		c := policyConstraint{
+			Path:         path,
+			Type:         rule.Type,
+			Value:        policyValueString(rule.Value),
+			Pattern:      rule.Pattern,
+			DefaultValue: policyValueString(rule.DefaultValue),
+			IsOptional:   rule.IsOptional,
+			Hidden:       rule.Hidden,
+		}
+		for _, v := range rule.Values {
+			c.Values = append(c.Values, policyValueString(v))
+		}
+		if rule.MinValue != nil {
+			c.MinValue = *rule.MinValue
+		}
+		if rule.MaxValue != nil {
+			c.MaxValue = *rule.MaxValue
+		}
+		constraints = append(constraints, c)
+		if rule.Type == "fixed" {
+			defaults[path] = c.Value
+		} else if rule.DefaultValue != nil {
+			defaults[path] = c.DefaultValue
+		}
+	}
+	sort.Slice(constraints, func(i, j int) bool {
+		return constraints[i].Path < constraints[j].Path
+	})
+	return constraints, defaults, nil
+}
+
+// DataSourceEffectivePolicy returns the effective definition of the cluster policy or
+// of the policy family with overrides
+func DataSourceEffectivePolicy() *schema.Resource {
+	return common.WorkspaceData(func(ctx context.Context, data *struct {
+		PolicyId                        string             `json:"policy_id,omitempty" tf:"computed"`
+		Name                            string             `json:"name,omitempty" tf:"computed"`
+		PolicyFamilyId                  string             `json:"policy_family_id,omitempty" tf:"computed"`
+		PolicyFamilyDefinitionOverrides string             `json:"policy_family_definition_overrides,omitempty" tf:"computed"`
+		Definition                      string             `json:"definition,omitempty" tf:"computed"`
+		Constraints                     []policyConstraint `json:"constraints,omitempty" tf:"computed"`
+		DefaultValues                   map[string]string  `json:"default_values,omitempty" tf:"computed"`
+	}, w *databricks.WorkspaceClient) error {
+		switch {
+		case data.PolicyId != "" || data.Name != "":
+			var policy *compute.Policy
+			var err error
+			if data.PolicyId != "" {
+				policy, err = w.ClusterPolicies.GetByPolicyId(ctx, data.PolicyId)
+			} else {
+				policy, err = w.ClusterPolicies.GetByName(ctx, data.Name)
+			}
+			if err != nil {
+				return err
+			}
+			data.PolicyId = policy.PolicyId
+			data.Name = policy.Name
+			data.PolicyFamilyId = policy.PolicyFamilyId
+			data.PolicyFamilyDefinitionOverrides = policy.PolicyFamilyDefinitionOverrides
+			data.Definition = policy.Definition
+		case data.PolicyFamilyId == "":
+			return fmt.Errorf("one of `policy_id`, `name` or `policy_family_id` must be specified")
+		}
+		if data.PolicyFamilyId != "" {
+			family, err := w.PolicyFamilies.GetByPolicyFamilyId(ctx, data.PolicyFamilyId)
+			if err != nil {
+				return err
+			}
+			data.Definition, err = mergePolicyDefinition(family.Definition, data.PolicyFamilyDefinitionOverrides)
+			if err != nil {
+				return err
+			}
+		}
+		var err error
+		data.Constraints, data.DefaultValues, err = policyConstraints(data.Definition)
+		return err
+	})
+}
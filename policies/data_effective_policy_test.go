@@ -0,0 +1,107 @@
+package policies
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicyFamily = compute.PolicyFamily{
+	PolicyFamilyId: "personal-vm",
+	Name:           "Personal Compute",
+	Definition: `{
+		"node_type_id": {"type": "allowlist", "values": ["i3.xlarge", "i3.2xlarge"], "defaultValue": "i3.xlarge"},
+		"num_workers": {"type": "fixed", "value": 0},
+		"autotermination_minutes": {"type": "range", "minValue": 10, "maxValue": 120}
+	}`,
+}
+
+func TestMergePolicyDefinition(t *testing.T) {
+	merged, err := mergePolicyDefinition(testPolicyFamily.Definition,
+		`{"autotermination_minutes": {"type": "fixed", "value": 30}, "custom_tags.team": {"type": "fixed", "value": "data"}}`)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"autotermination_minutes": {"type": "fixed", "value": 30},
+		"custom_tags.team": {"type": "fixed", "value": "data"},
+		"node_type_id": {"type": "allowlist", "values": ["i3.xlarge", "i3.2xlarge"], "defaultValue": "i3.xlarge"},
+		"num_workers": {"type": "fixed", "value": 0}
+	}`, merged)
+
+	_, err = mergePolicyDefinition(testPolicyFamily.Definition, `[]`)
+	assert.ErrorContains(t, err, "can't parse policy_family_definition_overrides")
+}
+
+func TestDataSourceEffectivePolicy_Family(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policy-families/personal-vm?",
+				Response: testPolicyFamily,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceEffectivePolicy(),
+		ID:          ".",
+		State: map[string]any{
+			"policy_family_id":                   "personal-vm",
+			"policy_family_definition_overrides": `{"autotermination_minutes": {"type": "fixed", "value": 30}}`,
+		},
+	}.ApplyAndExpectData(t, map[string]any{
+		"definition": `{"autotermination_minutes":{"type":"fixed","value":30},` +
+			`"node_type_id":{"type":"allowlist","values":["i3.xlarge","i3.2xlarge"],"defaultValue":"i3.xlarge"},` +
+			`"num_workers":{"type":"fixed","value":0}}`,
+		"constraints.#":               3,
+		"constraints.0.path":          "autotermination_minutes",
+		"constraints.0.type":          "fixed",
+		"constraints.0.value":         "30",
+		"constraints.1.path":          "node_type_id",
+		"constraints.1.values":        []any{"i3.xlarge", "i3.2xlarge"},
+		"constraints.1.default_value": "i3.xlarge",
+		"default_values": map[string]any{
+			"autotermination_minutes": "30",
+			"node_type_id":            "i3.xlarge",
+			"num_workers":             "0",
+		},
+	})
+}
+
+func TestDataSourceEffectivePolicy_Policy(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: compute.Policy{
+					PolicyId:   "abc",
+					Name:       "Shared",
+					Definition: `{"spark_version": {"type": "regex", "pattern": "13\\..*"}}`,
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceEffectivePolicy(),
+		ID:          ".",
+		HCL:         `policy_id = "abc"`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"name":                  "Shared",
+		"definition":            `{"spark_version": {"type": "regex", "pattern": "13\\..*"}}`,
+		"constraints.0.path":    "spark_version",
+		"constraints.0.pattern": `13\..*`,
+	})
+}
+
+func TestDataSourceEffectivePolicy_NoArguments(t *testing.T) {
+	qa.ResourceFixture{
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceEffectivePolicy(),
+		ID:          ".",
+		HCL:         ``,
+	}.ExpectError(t, "one of `policy_id`, `name` or `policy_family_id` must be specified")
+}
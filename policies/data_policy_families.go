@@ -0,0 +1,46 @@
+package policies
+
+import (
+	"context"
+	"sort"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type policyFamily struct {
+	PolicyFamilyId string `json:"policy_family_id"`
+	Name           string `json:"name"`
+	Description    string `json:"description,omitempty"`
+	Definition     string `json:"definition"`
+}
+
+// DataSourcePolicyFamilies returns all available policy families
+func DataSourcePolicyFamilies() *schema.Resource {
+	return common.WorkspaceData(func(ctx context.Context, data *struct {
+		PolicyFamilies []policyFamily    `json:"policy_families,omitempty" tf:"computed"`
+		Ids            map[string]string `json:"ids,omitempty" tf:"computed"`
+	}, w *databricks.WorkspaceClient) error {
+		families, err := w.PolicyFamilies.ListAll(ctx, compute.ListPolicyFamiliesRequest{})
+		if err != nil {
+			return err
+		}
+		sort.Slice(families, func(i, j int) bool {
+			return families[i].Name < families[j].Name
+		})
+		data.PolicyFamilies = []policyFamily{}
+		data.Ids = map[string]string{}
+		for _, f := range families {
+			data.PolicyFamilies = append(data.PolicyFamilies, policyFamily{
+				PolicyFamilyId: f.PolicyFamilyId,
+				Name:           f.Name,
+				Description:    f.Description,
+				Definition:     f.Definition,
+			})
+			data.Ids[f.Name] = f.PolicyFamilyId
+		}
+		return nil
+	})
+}
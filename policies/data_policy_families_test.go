@@ -0,0 +1,48 @@
+package policies
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/qa"
+)
+
+func TestDataSourcePolicyFamilies(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policy-families?",
+				Response: compute.ListPolicyFamiliesResponse{
+					PolicyFamilies: []compute.PolicyFamily{
+						{
+							PolicyFamilyId: "def",
+							Name:           "Personal Compute",
+							Description:    "Use with small-to-medium data",
+							Definition:     `{"node_type_id":{"type":"allowlist","values":["i3.xlarge"]}}`,
+						},
+						{
+							PolicyFamilyId: "abc",
+							Name:           "Job Compute",
+							Definition:     `{"cluster_type":{"type":"fixed","value":"job"}}`,
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourcePolicyFamilies(),
+		ID:          ".",
+	}.ApplyAndExpectData(t, map[string]any{
+		"ids": map[string]any{
+			"Personal Compute": "def",
+			"Job Compute":      "abc",
+		},
+		"policy_families.#":                  2,
+		"policy_families.0.policy_family_id": "abc",
+		"policy_families.0.definition":       `{"cluster_type":{"type":"fixed","value":"job"}}`,
+		"policy_families.1.name":             "Personal Compute",
+		"policy_families.1.description":      "Use with small-to-medium data",
+	})
+}
@@ -0,0 +1,300 @@
+package pools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	ws_api "github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/databricks/terraform-provider-databricks/common"
+)
+
+// DefaultScheduleDirectory is the workspace directory where schedules of instance pools are stored by default,
+// so that they could be reconciled outside of Terraform. Every workspace user can modify files in /Shared,
+// so schedules that shouldn't be changed by other users have to be stored in a directory with restricted permissions.
+const DefaultScheduleDirectory = "/Shared/.instance_pool_schedules"
+
+var (
+	scheduleDays       = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+	scheduleTimeRegexp = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+)
+
+// ScheduleWindow defines the capacity of an instance pool during a recurring time window
+type ScheduleWindow struct {
+	DaysOfWeek       []string `json:"days_of_week,omitempty"`
+	StartTime        string   `json:"start_time"`
+	EndTime          string   `json:"end_time"`
+	MinIdleInstances int32    `json:"min_idle_instances"`
+	MaxCapacity      int32    `json:"max_capacity,omitempty"`
+}
+
+// InstancePoolSchedule defines the capacity of an instance pool depending on the time of day and day of week.
+// Outside of all windows the top-level capacity is used.
+type InstancePoolSchedule struct {
+	InstancePoolID   string           `json:"instance_pool_id" tf:"force_new"`
+	Timezone         string           `json:"timezone,omitempty" tf:"default:UTC"`
+	MinIdleInstances int32            `json:"min_idle_instances"`
+	MaxCapacity      int32            `json:"max_capacity,omitempty"`
+	Windows          []ScheduleWindow `json:"windows,omitempty" tf:"alias:window"`
+}
+
+// minuteOfDay converts HH:MM into the number of minutes since midnight
+func minuteOfDay(hhmm string) (int, error) {
+	if !scheduleTimeRegexp.MatchString(hhmm) {
+		return 0, fmt.Errorf("invalid time %s, expected HH:MM", hhmm)
+	}
+	hours, _ := strconv.Atoi(hhmm[0:2])
+	minutes, _ := strconv.Atoi(hhmm[3:5])
+	return hours*60 + minutes, nil
+}
+
+func hasDay(days []string, weekday time.Weekday) bool {
+	if len(days) == 0 {
+		return true
+	}
+	for _, day := range days {
+		if strings.EqualFold(day, scheduleDays[weekday]) {
+			return true
+		}
+	}
+	return false
+}
+
+// isActive returns true if the window includes the given local time. Windows that end before
+// they start are spanning midnight, so their end belongs to the next day.
+func (w ScheduleWindow) isActive(local time.Time) (bool, error) {
+	start, err := minuteOfDay(w.StartTime)
+	if err != nil {
+		return false, err
+	}
+	end, err := minuteOfDay(w.EndTime)
+	if err != nil {
+		return false, err
+	}
+	now := local.Hour()*60 + local.Minute()
+	if start < end {
+		return hasDay(w.DaysOfWeek, local.Weekday()) && now >= start && now < end, nil
+	}
+	if now >= start {
+		return hasDay(w.DaysOfWeek, local.Weekday()), nil
+	}
+	yesterday := local.AddDate(0, 0, -1).Weekday()
+	return now < end && hasDay(w.DaysOfWeek, yesterday), nil
+}
+
+// validateCapacity checks that max capacity isn't lower than min idle instances,
+// as the instance pool can't be updated with such capacity
+func validateCapacity(name string, minIdleInstances, maxCapacity int32) error {
+	if maxCapacity != 0 && maxCapacity < minIdleInstances {
+		return fmt.Errorf("%s max_capacity (%d) can't be lower than min_idle_instances (%d)",
+			name, maxCapacity, minIdleInstances)
+	}
+	return nil
+}
+
+// Validate checks capacity outside of and during every window
+func (s InstancePoolSchedule) Validate() error {
+	err := validateCapacity("schedule", s.MinIdleInstances, s.MaxCapacity)
+	if err != nil {
+		return err
+	}
+	for i, w := range s.Windows {
+		err = validateCapacity(fmt.Sprintf("window %d", i), w.MinIdleInstances, w.MaxCapacity)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Capacity returns min idle instances and max capacity of the pool at the given time. The first matching
+// window is used. Zero max capacity means that the max capacity of the pool shouldn't be changed.
+func (s InstancePoolSchedule) Capacity(now time.Time) (minIdleInstances int32, maxCapacity int32, err error) {
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid timezone: %w", err)
+	}
+	local := now.In(location)
+	for _, w := range s.Windows {
+		active, err := w.isActive(local)
+		if err != nil {
+			return 0, 0, err
+		}
+		if active {
+			return w.MinIdleInstances, w.MaxCapacity, nil
+		}
+	}
+	return s.MinIdleInstances, s.MaxCapacity, nil
+}
+
+// InstancePoolSchedulesAPI stores schedules of instance pools as files in a workspace directory
+type InstancePoolSchedulesAPI struct {
+	client    *common.DatabricksClient
+	context   context.Context
+	directory string
+}
+
+// NewInstancePoolSchedulesAPI creates InstancePoolSchedulesAPI instance from provider meta,
+// that uses DefaultScheduleDirectory
+func NewInstancePoolSchedulesAPI(ctx context.Context, m any) InstancePoolSchedulesAPI {
+	return InstancePoolSchedulesAPI{m.(*common.DatabricksClient), ctx, DefaultScheduleDirectory}
+}
+
+// InDirectory returns InstancePoolSchedulesAPI that stores schedules in the given directory
+func (a InstancePoolSchedulesAPI) InDirectory(directory string) InstancePoolSchedulesAPI {
+	if directory != "" {
+		a.directory = directory
+	}
+	return a
+}
+
+// SchedulePath returns the workspace path of the schedule for the given instance pool
+func (a InstancePoolSchedulesAPI) SchedulePath(instancePoolID string) string {
+	return path.Join(a.directory, instancePoolID+".json")
+}
+
+// Save creates or overwrites the schedule of the instance pool
+func (a InstancePoolSchedulesAPI) Save(schedule InstancePoolSchedule) error {
+	w, err := a.client.WorkspaceClient()
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(schedule, "", "  ")
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(a.directory+"/", "/Shared/") {
+		log.Printf("[WARN] Schedule of instance pool %s is stored in %s, where every workspace user can change it",
+			schedule.InstancePoolID, a.directory)
+	}
+	err = w.Workspace.MkdirsByPath(a.context, a.directory)
+	if err != nil {
+		return err
+	}
+	return w.Workspace.Import(a.context, ws_api.Import{
+		Content:   base64.StdEncoding.EncodeToString(content),
+		Format:    ws_api.ImportFormatAuto,
+		Path:      a.SchedulePath(schedule.InstancePoolID),
+		Overwrite: true,
+	})
+}
+
+// Read returns the schedule of the instance pool
+func (a InstancePoolSchedulesAPI) Read(instancePoolID string) (schedule InstancePoolSchedule, err error) {
+	w, err := a.client.WorkspaceClient()
+	if err != nil {
+		return
+	}
+	exported, err := w.Workspace.Export(a.context, ws_api.ExportRequest{
+		Format: ws_api.ExportFormatSource,
+		Path:   a.SchedulePath(instancePoolID),
+	})
+	if err != nil {
+		return
+	}
+	content, err := base64.StdEncoding.DecodeString(exported.Content)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(content, &schedule)
+	if err != nil {
+		err = fmt.Errorf("can't parse schedule of %s: %w", instancePoolID, err)
+	}
+	return
+}
+
+// Delete removes the schedule of the instance pool
+func (a InstancePoolSchedulesAPI) Delete(instancePoolID string) error {
+	w, err := a.client.WorkspaceClient()
+	if err != nil {
+		return err
+	}
+	return w.Workspace.Delete(a.context, ws_api.Delete{
+		Path: a.SchedulePath(instancePoolID),
+	})
+}
+
+// List returns IDs of instance pools that have schedules
+func (a InstancePoolSchedulesAPI) List() ([]string, error) {
+	w, err := a.client.WorkspaceClient()
+	if err != nil {
+		return nil, err
+	}
+	objects, err := w.Workspace.ListAll(a.context, ws_api.ListWorkspaceRequest{
+		Path: a.directory,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, object := range objects {
+		name := path.Base(object.Path)
+		if object.ObjectType == ws_api.ObjectTypeDirectory || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(name, ".json"))
+	}
+	return ids, nil
+}
+
+// Reconcile updates capacity of the instance pool according to its schedule at the given time.
+// It returns true if the instance pool was updated.
+func (a InstancePoolSchedulesAPI) Reconcile(schedule InstancePoolSchedule, now time.Time, dryRun bool) (bool, error) {
+	minIdleInstances, maxCapacity, err := schedule.Capacity(now)
+	if err != nil {
+		return false, err
+	}
+	poolsAPI := NewInstancePoolsAPI(a.context, a.client)
+	pool, err := poolsAPI.Read(schedule.InstancePoolID)
+	if err != nil {
+		return false, err
+	}
+	if maxCapacity == 0 {
+		maxCapacity = pool.MaxCapacity
+	}
+	if pool.MinIdleInstances == minIdleInstances && pool.MaxCapacity == maxCapacity {
+		log.Printf("[DEBUG] Instance pool %s already has %d min idle instances and %d max capacity",
+			schedule.InstancePoolID, minIdleInstances, maxCapacity)
+		return false, nil
+	}
+	log.Printf("[INFO] Changing instance pool %s from %d to %d min idle instances and from %d to %d max capacity",
+		schedule.InstancePoolID, pool.MinIdleInstances, minIdleInstances, pool.MaxCapacity, maxCapacity)
+	if dryRun {
+		return true, nil
+	}
+	pool.InstancePoolID = schedule.InstancePoolID
+	pool.MinIdleInstances = minIdleInstances
+	pool.MaxCapacity = maxCapacity
+	return true, poolsAPI.Update(pool)
+}
+
+// ReconcileAll updates capacity of all instance pools that have schedules. Failure to reconcile
+// one instance pool doesn't prevent reconciliation of others.
+func (a InstancePoolSchedulesAPI) ReconcileAll(now time.Time, dryRun bool) error {
+	ids, err := a.List()
+	if err != nil {
+		return err
+	}
+	failures := []string{}
+	for _, id := range ids {
+		schedule, err := a.Read(id)
+		if err == nil {
+			_, err = a.Reconcile(schedule, now, dryRun)
+		}
+		if err != nil {
+			log.Printf("[ERROR] Can't reconcile instance pool %s: %v", id, err)
+			failures = append(failures, fmt.Sprintf("%s: %v", id, err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("can't reconcile %d instance pools: %s", len(failures), strings.Join(failures, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package pools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	ws_api "github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSchedule = InstancePoolSchedule{
+	InstancePoolID:   "abc",
+	Timezone:         "Europe/Amsterdam",
+	MinIdleInstances: 0,
+	Windows: []ScheduleWindow{
+		{
+			DaysOfWeek:       []string{"MON", "TUE", "WED", "THU", "FRI"},
+			StartTime:        "07:30",
+			EndTime:          "18:00",
+			MinIdleInstances: 10,
+			MaxCapacity:      100,
+		},
+		{
+			DaysOfWeek:       []string{"FRI"},
+			StartTime:        "22:00",
+			EndTime:          "02:00",
+			MinIdleInstances: 2,
+		},
+	},
+}
+
+func exportedSchedule(t *testing.T, schedule InstancePoolSchedule) ws_api.ExportResponse {
+	content, err := json.MarshalIndent(schedule, "", "  ")
+	require.NoError(t, err)
+	return ws_api.ExportResponse{
+		Content: base64.StdEncoding.EncodeToString(content),
+	}
+}
+
+func TestInstancePoolScheduleCapacity(t *testing.T) {
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	require.NoError(t, err)
+	for _, tc := range []struct {
+		name        string
+		now         time.Time
+		minIdle     int32
+		maxCapacity int32
+	}{
+		{"business hours", time.Date(2023, 10, 2, 9, 0, 0, 0, amsterdam), 10, 100},
+		{"start is inclusive", time.Date(2023, 10, 2, 7, 30, 0, 0, amsterdam), 10, 100},
+		{"end is exclusive", time.Date(2023, 10, 2, 18, 0, 0, 0, amsterdam), 0, 0},
+		{"timezone is applied", time.Date(2023, 10, 2, 6, 0, 0, 0, time.UTC), 10, 100},
+		{"weekend", time.Date(2023, 10, 7, 9, 0, 0, 0, amsterdam), 0, 0},
+		{"friday night", time.Date(2023, 10, 6, 23, 0, 0, 0, amsterdam), 2, 0},
+		{"after midnight", time.Date(2023, 10, 7, 1, 0, 0, 0, amsterdam), 2, 0},
+		{"after midnight of another day", time.Date(2023, 10, 6, 1, 0, 0, 0, amsterdam), 0, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			minIdle, maxCapacity, err := testSchedule.Capacity(tc.now)
+			require.NoError(t, err)
+			assert.Equal(t, tc.minIdle, minIdle)
+			assert.Equal(t, tc.maxCapacity, maxCapacity)
+		})
+	}
+}
+
+func TestInstancePoolScheduleCapacity_Errors(t *testing.T) {
+	_, _, err := InstancePoolSchedule{Timezone: "Mars/Olympus"}.Capacity(time.Now())
+	assert.ErrorContains(t, err, "invalid timezone")
+
+	_, _, err = InstancePoolSchedule{
+		Timezone: "UTC",
+		Windows:  []ScheduleWindow{{StartTime: "7:30", EndTime: "18:00"}},
+	}.Capacity(time.Now())
+	assert.EqualError(t, err, "invalid time 7:30, expected HH:MM")
+}
+
+func TestInstancePoolSchedulesReconcileAll(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/list?path=%2FShared%2F.instance_pool_schedules",
+			Response: ws_api.ListResponse{
+				Objects: []ws_api.ObjectInfo{
+					{
+						Path:       "/Shared/.instance_pool_schedules/abc.json",
+						ObjectType: ws_api.ObjectTypeFile,
+					},
+					{
+						Path:       "/Shared/.instance_pool_schedules/def.json",
+						ObjectType: ws_api.ObjectTypeFile,
+					},
+					{
+						Path:       "/Shared/.instance_pool_schedules/README.md",
+						ObjectType: ws_api.ObjectTypeFile,
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/export?format=SOURCE&path=%2FShared%2F.instance_pool_schedules%2Fabc.json",
+			Response: exportedSchedule(t, testSchedule),
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+			Response: InstancePool{
+				InstancePoolID:                     "abc",
+				InstancePoolName:                   "Shared Pool",
+				NodeTypeID:                         "i3.xlarge",
+				IdleInstanceAutoTerminationMinutes: 15,
+				MaxCapacity:                        50,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/instance-pools/edit",
+			ExpectedRequest: InstancePool{
+				InstancePoolID:                     "abc",
+				InstancePoolName:                   "Shared Pool",
+				NodeTypeID:                         "i3.xlarge",
+				IdleInstanceAutoTerminationMinutes: 15,
+				MinIdleInstances:                   10,
+				MaxCapacity:                        100,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/export?format=SOURCE&path=%2FShared%2F.instance_pool_schedules%2Fdef.json",
+			Response: ws_api.ExportResponse{
+				Content: base64.StdEncoding.EncodeToString([]byte("{")),
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		now := time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC)
+		err := NewInstancePoolSchedulesAPI(ctx, client).ReconcileAll(now, false)
+		assert.EqualError(t, err, "can't reconcile 1 instance pools: def: "+
+			"can't parse schedule of def: unexpected end of JSON input")
+	})
+}
+
+func TestInstancePoolSchedulesReconcile_NoChanges(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+			Response: InstancePool{
+				InstancePoolID:   "abc",
+				MinIdleInstances: 10,
+				MaxCapacity:      100,
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+			Response: InstancePool{
+				InstancePoolID: "abc",
+				MaxCapacity:    100,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		a := NewInstancePoolSchedulesAPI(ctx, client)
+		now := time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC)
+		updated, err := a.Reconcile(testSchedule, now, false)
+		require.NoError(t, err)
+		assert.False(t, updated)
+
+		// dry run doesn't edit the pool
+		updated, err = a.Reconcile(testSchedule, now, true)
+		require.NoError(t, err)
+		assert.True(t, updated)
+	})
+}
+
+func TestInstancePoolScheduleValidate(t *testing.T) {
+	assert.NoError(t, testSchedule.Validate())
+	assert.EqualError(t, InstancePoolSchedule{
+		MinIdleInstances: 5,
+		MaxCapacity:      2,
+	}.Validate(), "schedule max_capacity (2) can't be lower than min_idle_instances (5)")
+}
@@ -0,0 +1,79 @@
+package pools
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceInstancePoolSchedule manages time-based capacity of instance pools
+func ResourceInstancePoolSchedule() *schema.Resource {
+	s := common.StructToSchema(InstancePoolSchedule{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["timezone"].ValidateFunc = func(i any, k string) (_ []string, errs []error) {
+			if _, err := time.LoadLocation(i.(string)); err != nil {
+				errs = append(errs, err)
+			}
+			return
+		}
+		s["min_idle_instances"].ValidateFunc = validation.IntAtLeast(0)
+		common.MustSchemaPath(s, "window", "min_idle_instances").ValidateFunc = validation.IntAtLeast(0)
+		for _, field := range []string{"start_time", "end_time"} {
+			common.MustSchemaPath(s, "window", field).ValidateFunc = validation.StringMatch(
+				scheduleTimeRegexp, "should be in HH:MM format")
+		}
+		common.MustSchemaPath(s, "window", "days_of_week").Elem.(*schema.Schema).ValidateFunc =
+			validation.StringInSlice(scheduleDays, false)
+		s["directory"] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      DefaultScheduleDirectory,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^/`), "should be an absolute workspace path"),
+		}
+		return s
+	})
+	schedulesAPI := func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) InstancePoolSchedulesAPI {
+		return NewInstancePoolSchedulesAPI(ctx, c).InDirectory(d.Get("directory").(string))
+	}
+	return common.Resource{
+		Schema: s,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff) error {
+			var schedule InstancePoolSchedule
+			common.DiffToStructPointer(d, s, &schedule)
+			return schedule.Validate()
+		},
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var schedule InstancePoolSchedule
+			common.DataToStructPointer(d, s, &schedule)
+			err := schedulesAPI(ctx, d, c).Save(schedule)
+			if err != nil {
+				return err
+			}
+			d.SetId(schedule.InstancePoolID)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			a := schedulesAPI(ctx, d, c)
+			schedule, err := a.Read(d.Id())
+			if err != nil {
+				return err
+			}
+			d.Set("directory", a.directory)
+			return common.StructToData(schedule, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var schedule InstancePoolSchedule
+			common.DataToStructPointer(d, s, &schedule)
+			schedule.InstancePoolID = d.Id()
+			return schedulesAPI(ctx, d, c).Save(schedule)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			return schedulesAPI(ctx, d, c).Delete(d.Id())
+		},
+	}.ToResource()
+}
@@ -0,0 +1,208 @@
+package pools
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	ws_api "github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceInstancePoolScheduleCornerCases(t *testing.T) {
+	qa.ResourceCornerCases(t, ResourceInstancePoolSchedule())
+}
+
+func TestResourceInstancePoolScheduleCreate(t *testing.T) {
+	content, err := json.MarshalIndent(testSchedule, "", "  ")
+	require.NoError(t, err)
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/workspace/mkdirs",
+				ExpectedRequest: ws_api.Mkdirs{
+					Path: "/Shared/.instance_pool_schedules",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ws_api.Import{
+					Content:   base64.StdEncoding.EncodeToString(content),
+					Format:    ws_api.ImportFormatAuto,
+					Path:      "/Shared/.instance_pool_schedules/abc.json",
+					Overwrite: true,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/export?format=SOURCE&path=%2FShared%2F.instance_pool_schedules%2Fabc.json",
+				Response: exportedSchedule(t, testSchedule),
+			},
+		},
+		Resource: ResourceInstancePoolSchedule(),
+		Create:   true,
+		HCL: `
+		instance_pool_id = "abc"
+		timezone = "Europe/Amsterdam"
+		min_idle_instances = 0
+
+		window {
+			days_of_week = ["MON", "TUE", "WED", "THU", "FRI"]
+			start_time = "07:30"
+			end_time = "18:00"
+			min_idle_instances = 10
+			max_capacity = 100
+		}
+
+		window {
+			days_of_week = ["FRI"]
+			start_time = "22:00"
+			end_time = "02:00"
+			min_idle_instances = 2
+		}`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 2, d.Get("window.#"))
+}
+
+func TestResourceInstancePoolScheduleCreate_InvalidTime(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceInstancePoolSchedule(),
+		Create:   true,
+		HCL: `
+		instance_pool_id = "abc"
+		min_idle_instances = 0
+
+		window {
+			start_time = "7:30"
+			end_time = "18:00"
+			min_idle_instances = 10
+		}`,
+	}.ExpectError(t, "invalid config supplied. [window.#.start_time] invalid value for window.0.start_time (should be in HH:MM format)")
+}
+
+func TestResourceInstancePoolScheduleRead_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/export?format=SOURCE&path=%2FShared%2F.instance_pool_schedules%2Fabc.json",
+				Response: apierr.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Path doesn't exist",
+				},
+				Status: 404,
+			},
+		},
+		Resource: ResourceInstancePoolSchedule(),
+		Read:     true,
+		Removed:  true,
+		New:      true,
+		ID:       "abc",
+	}.ApplyNoError(t)
+}
+
+func TestResourceInstancePoolScheduleDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/workspace/delete",
+				ExpectedRequest: ws_api.Delete{
+					Path: "/Shared/.instance_pool_schedules/abc.json",
+				},
+			},
+		},
+		Resource: ResourceInstancePoolSchedule(),
+		Delete:   true,
+		ID:       "abc",
+	}.ApplyNoError(t)
+}
+
+func TestResourceInstancePoolScheduleCreate_CustomDirectory(t *testing.T) {
+	schedule := InstancePoolSchedule{
+		InstancePoolID:   "abc",
+		Timezone:         "UTC",
+		MinIdleInstances: 1,
+	}
+	content, err := json.MarshalIndent(schedule, "", "  ")
+	require.NoError(t, err)
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/workspace/mkdirs",
+				ExpectedRequest: ws_api.Mkdirs{
+					Path: "/Platform/pool_schedules",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ws_api.Import{
+					Content:   base64.StdEncoding.EncodeToString(content),
+					Format:    ws_api.ImportFormatAuto,
+					Path:      "/Platform/pool_schedules/abc.json",
+					Overwrite: true,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/export?format=SOURCE&path=%2FPlatform%2Fpool_schedules%2Fabc.json",
+				Response: exportedSchedule(t, schedule),
+			},
+		},
+		Resource: ResourceInstancePoolSchedule(),
+		Create:   true,
+		HCL: `
+		instance_pool_id = "abc"
+		min_idle_instances = 1
+		directory = "/Platform/pool_schedules"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":        "abc",
+		"directory": "/Platform/pool_schedules",
+	})
+}
+
+func TestResourceInstancePoolScheduleCreate_MaxCapacityBelowMinIdle(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceInstancePoolSchedule(),
+		Create:   true,
+		HCL: `
+		instance_pool_id = "abc"
+		min_idle_instances = 0
+
+		window {
+			start_time = "07:30"
+			end_time = "18:00"
+			min_idle_instances = 10
+			max_capacity = 5
+		}`,
+	}.ExpectError(t, "window 0 max_capacity (5) can't be lower than min_idle_instances (10)")
+}
+
+func TestResourceInstancePoolScheduleRead_Imported(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/export?format=SOURCE&path=%2FShared%2F.instance_pool_schedules%2Fabc.json",
+				Response: exportedSchedule(t, testSchedule),
+			},
+		},
+		Resource: ResourceInstancePoolSchedule(),
+		Read:     true,
+		New:      true,
+		ID:       "abc",
+	}.ApplyAndExpectData(t, map[string]any{
+		"directory": DefaultScheduleDirectory,
+		"window.#":  2,
+	})
+}
@@ -0,0 +1,38 @@
+package pools
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/client"
+	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/databricks/terraform-provider-databricks/common"
+)
+
+// RunScheduleReconciler applies capacity from `databricks_instance_pool_schedule` resources to instance pools.
+// It's meant to be run periodically, e.g. from cron, with the same authentication environment as the provider.
+func RunScheduleReconciler(args ...string) error {
+	flags := flag.NewFlagSet("reconcile-pool-schedules", flag.ExitOnError)
+	var dryRun bool
+	var directory string
+	flags.BoolVar(&dryRun, "dry-run", false, "Only print changes of instance pools without applying them")
+	flags.StringVar(&directory, "directory", DefaultScheduleDirectory,
+		"Workspace directory with schedules, the same as `directory` of databricks_instance_pool_schedule")
+	newArgs := args
+	if len(args) > 1 && args[1] == "reconcile-pool-schedules" {
+		newArgs = args[2:]
+	}
+	err := flags.Parse(newArgs)
+	if err != nil {
+		return err
+	}
+	databricksClient, err := client.New(&config.Config{})
+	if err != nil {
+		return err
+	}
+	c := &common.DatabricksClient{
+		DatabricksClient: databricksClient,
+	}
+	return NewInstancePoolSchedulesAPI(context.Background(), c).InDirectory(directory).ReconcileAll(time.Now(), dryRun)
+}
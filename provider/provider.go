@@ -60,23 +60,29 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_clusters":                clusters.DataSourceClusters(),
 			"databricks_cluster_policy":          policies.DataSourceClusterPolicy(),
 			"databricks_catalogs":                catalog.DataSourceCatalogs(),
+			"databricks_compute_cost_estimate":   clusters.DataSourceComputeCostEstimate(),
 			"databricks_current_user":            scim.DataSourceCurrentUser(),
 			"databricks_dbfs_file":               storage.DataSourceDbfsFile(),
 			"databricks_dbfs_file_paths":         storage.DataSourceDbfsFilePaths(),
 			"databricks_directory":               workspace.DataSourceDirectory(),
+			"databricks_effective_policy":        policies.DataSourceEffectivePolicy(),
 			"databricks_group":                   scim.DataSourceGroup(),
 			"databricks_instance_pool":           pools.DataSourceInstancePool(),
 			"databricks_jobs":                    jobs.DataSourceJobs(),
 			"databricks_job":                     jobs.DataSourceJob(),
+			"databricks_job_run_output":          jobs.DataSourceJobRunOutput(),
+			"databricks_job_runs":                jobs.DataSourceJobRuns(),
 			"databricks_metastore":               catalog.DataSourceMetastore(),
 			"databricks_metastores":              catalog.DataSourceMetastores(),
 			"databricks_mlflow_model":            mlflow.DataSourceModel(),
+			"databricks_mount_migration":         storage.DataSourceMountMigration(),
 			"databricks_mws_credentials":         mws.DataSourceMwsCredentials(),
 			"databricks_mws_workspaces":          mws.DataSourceMwsWorkspaces(),
 			"databricks_node_type":               clusters.DataSourceNodeType(),
 			"databricks_notebook":                workspace.DataSourceNotebook(),
 			"databricks_notebook_paths":          workspace.DataSourceNotebookPaths(),
 			"databricks_pipelines":               pipelines.DataSourcePipelines(),
+			"databricks_policy_families":         policies.DataSourcePolicyFamilies(),
 			"databricks_schemas":                 catalog.DataSourceSchemas(),
 			"databricks_service_principal":       scim.DataSourceServicePrincipal(),
 			"databricks_service_principals":      scim.DataSourceServicePrincipals(),
@@ -114,9 +120,11 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_group_member":                scim.ResourceGroupMember(),
 			"databricks_group_role":                  scim.ResourceGroupRole(),
 			"databricks_instance_pool":               pools.ResourceInstancePool(),
+			"databricks_instance_pool_schedule":      pools.ResourceInstancePoolSchedule(),
 			"databricks_instance_profile":            aws.ResourceInstanceProfile(),
 			"databricks_ip_access_list":              access.ResourceIPAccessList(),
 			"databricks_job":                         jobs.ResourceJob(),
+			"databricks_job_run":                     jobs.ResourceJobRun(),
 			"databricks_library":                     clusters.ResourceLibrary(),
 			"databricks_metastore":                   catalog.ResourceMetastore(),
 			"databricks_metastore_assignment":        catalog.ResourceMetastoreAssignment(),
@@ -0,0 +1,233 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/files"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var nonIdentifierRE = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// maxParityChecks is the number of entries of the mount, that are looked up in the volume
+const maxParityChecks = 5
+
+type mountMigration struct {
+	MountName                string   `json:"mount_name"`
+	Source                   string   `json:"source"`
+	CatalogName              string   `json:"catalog_name"`
+	SchemaName               string   `json:"schema_name"`
+	VolumeName               string   `json:"volume_name"`
+	CredentialName           string   `json:"credential_name,omitempty"`
+	ExternalLocationName     string   `json:"external_location_name,omitempty" tf:"computed"`
+	ExistingExternalLocation string   `json:"existing_external_location,omitempty" tf:"computed"`
+	URL                      string   `json:"url,omitempty" tf:"computed"`
+	MountPath                string   `json:"mount_path,omitempty" tf:"computed"`
+	VolumePath               string   `json:"volume_path,omitempty" tf:"computed"`
+	CheckedPaths             []string `json:"checked_paths,omitempty" tf:"computed"`
+	Hcl                      string   `json:"hcl,omitempty" tf:"computed"`
+}
+
+// unityCatalogURL converts source of a mount to the URL, that could be used by Unity Catalog,
+// where the same files are accessible. Unity Catalog supports only s3://, abfss:// and gs:// URLs.
+func unityCatalogURL(source string) (string, error) {
+	scheme, rest, ok := strings.Cut(source, "://")
+	if !ok || rest == "" {
+		return "", fmt.Errorf("invalid mount source: %s", source)
+	}
+	rest = strings.TrimRight(rest, "/")
+	switch scheme {
+	case "s3", "s3a", "s3n":
+		return "s3://" + rest, nil
+	case "abfs", "abfss":
+		return "abfss://" + rest, nil
+	case "wasb", "wasbs":
+		// the same container is accessible through the DFS endpoint only if hierarchical namespace is enabled
+		authority, path, _ := strings.Cut(rest, "/")
+		container, host, _ := strings.Cut(authority, "@")
+		account := strings.TrimSuffix(host, ".blob.core.windows.net")
+		if account == host || container == "" {
+			return "", fmt.Errorf("invalid Azure Blob Storage source: %s", source)
+		}
+		url := fmt.Sprintf("abfss://%s@%s.dfs.core.windows.net", container, account)
+		if path != "" {
+			url += "/" + path
+		}
+		return url, nil
+	case "gs":
+		return "gs://" + rest, nil
+	case "adl":
+		return "", fmt.Errorf("ADLS Gen1 isn't supported by Unity Catalog: %s", source)
+	default:
+		return "", fmt.Errorf("%s:// isn't supported by Unity Catalog: %s", scheme, source)
+	}
+}
+
+// isSubPath tells if the URL is the same or nested into the parent URL
+func isSubPath(url, parent string) bool {
+	parent = strings.TrimRight(parent, "/")
+	return url == parent || strings.HasPrefix(url, parent+"/")
+}
+
+// findExternalLocation returns the name of the external location, that covers the URL
+func (m *mountMigration) findExternalLocation(ctx context.Context, w *databricks.WorkspaceClient) error {
+	locations, err := w.ExternalLocations.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, location := range locations {
+		if isSubPath(m.URL, location.Url) {
+			m.ExistingExternalLocation = location.Name
+			m.ExternalLocationName = location.Name
+			return nil
+		}
+		if isSubPath(location.Url, m.URL) {
+			return fmt.Errorf("external location %s (%s) overlaps with %s, use a volume in it instead",
+				location.Name, location.Url, m.URL)
+		}
+	}
+	return nil
+}
+
+// checkVolume verifies that the volume either doesn't exist yet or points to the mount source.
+// It returns true if the volume exists.
+func (m *mountMigration) checkVolume(ctx context.Context, w *databricks.WorkspaceClient) (bool, error) {
+	fullName := fmt.Sprintf("%s.%s.%s", m.CatalogName, m.SchemaName, m.VolumeName)
+	volume, err := w.Volumes.ReadByFullNameArg(ctx, fullName)
+	if apierr.IsMissing(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if strings.TrimRight(volume.StorageLocation, "/") != m.URL {
+		return false, fmt.Errorf("volume %s already exists with storage location %s, but the mount points to %s",
+			fullName, volume.StorageLocation, m.URL)
+	}
+	return true, nil
+}
+
+// checkParity looks up the first few entries of the mount in the volume, because the actual source
+// of the mount can't be read without a cluster, so `source` might differ from it
+func (m *mountMigration) checkParity(ctx context.Context, w *databricks.WorkspaceClient) error {
+	entries, err := w.Dbfs.ListAll(ctx, files.ListDbfsRequest{
+		Path: m.MountPath,
+	})
+	if err != nil {
+		return err
+	}
+	m.CheckedPaths = []string{}
+	for _, entry := range entries {
+		if len(m.CheckedPaths) == maxParityChecks {
+			break
+		}
+		name := path.Base(entry.Path)
+		volumeEntry := path.Join(m.VolumePath, name)
+		info, err := w.Files.GetStatus(ctx, files.GetStatusRequest{
+			Path: volumeEntry,
+		})
+		if apierr.IsMissing(err) {
+			return fmt.Errorf("%s doesn't exist, but %s does, so the mount points to other files than %s",
+				volumeEntry, entry.Path, m.URL)
+		}
+		if err != nil {
+			return err
+		}
+		if info.IsDir != entry.IsDir || info.FileSize != entry.FileSize {
+			return fmt.Errorf("%s is different from %s, so the mount points to other files than %s",
+				volumeEntry, entry.Path, m.URL)
+		}
+		m.CheckedPaths = append(m.CheckedPaths, name)
+	}
+	return nil
+}
+
+// resourceName returns Terraform resource name for the mount, that must start with a letter or underscore
+func (m *mountMigration) resourceName() string {
+	name := nonIdentifierRE.ReplaceAllString(m.MountName, "_")
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "mount_" + name
+	}
+	return name
+}
+
+func (m *mountMigration) hcl() string {
+	name := m.resourceName()
+	comment := fmt.Sprintf("Migrated from dbfs:%s", m.MountPath)
+	var sb strings.Builder
+	storageLocation := fmt.Sprintf("%q", m.URL)
+	if m.ExistingExternalLocation == "" {
+		fmt.Fprintf(&sb, `resource "databricks_external_location" "%s" {
+  name            = %q
+  url             = %q
+  credential_name = %q
+  comment         = %q
+}
+
+`, name, m.ExternalLocationName, m.URL, m.CredentialName, comment)
+		storageLocation = fmt.Sprintf("databricks_external_location.%s.url", name)
+	}
+	fmt.Fprintf(&sb, `resource "databricks_volume" "%s" {
+  name             = %q
+  catalog_name     = %q
+  schema_name      = %q
+  volume_type      = "EXTERNAL"
+  storage_location = %s
+  comment          = %q
+}
+`, name, m.VolumeName, m.CatalogName, m.SchemaName, storageLocation, comment)
+	return sb.String()
+}
+
+// DataSourceMountMigration produces configuration of an external location and a volume,
+// that give access to the same files as a DBFS mount, without a cluster
+func DataSourceMountMigration() *schema.Resource {
+	return common.WorkspaceData(func(ctx context.Context, data *mountMigration, w *databricks.WorkspaceClient) error {
+		url, err := unityCatalogURL(data.Source)
+		if err != nil {
+			return err
+		}
+		data.URL = url
+		data.MountPath = "/mnt/" + data.MountName
+		data.VolumePath = fmt.Sprintf("/Volumes/%s/%s/%s", data.CatalogName, data.SchemaName, data.VolumeName)
+		_, err = w.Dbfs.GetStatusByPath(ctx, data.MountPath)
+		if apierr.IsMissing(err) {
+			return fmt.Errorf("mount %s doesn't exist", data.MountPath)
+		}
+		if err != nil {
+			return err
+		}
+		err = data.findExternalLocation(ctx, w)
+		if err != nil {
+			return err
+		}
+		if data.ExistingExternalLocation == "" {
+			if data.CredentialName == "" {
+				return fmt.Errorf("credential_name is required, because there's no external location for %s", url)
+			}
+			if data.ExternalLocationName == "" {
+				data.ExternalLocationName = "mount_" + nonIdentifierRE.ReplaceAllString(data.MountName, "_")
+			}
+		}
+		volumeExists, err := data.checkVolume(ctx, w)
+		if err != nil {
+			return err
+		}
+		if volumeExists {
+			err = data.checkParity(ctx, w)
+			if err != nil {
+				return err
+			}
+		}
+		data.Hcl = data.hcl()
+		return nil
+	})
+}
@@ -0,0 +1,325 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/databricks-sdk-go/service/files"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnityCatalogURL(t *testing.T) {
+	for source, expected := range map[string]string{
+		"s3a://bucket":                         "s3://bucket",
+		"s3n://bucket/dir/":                    "s3://bucket/dir",
+		"gs://bucket/dir":                      "gs://bucket/dir",
+		"abfss://c@a.dfs.core.windows.net/dir": "abfss://c@a.dfs.core.windows.net/dir",
+		"wasbs://c@a.blob.core.windows.net":    "abfss://c@a.dfs.core.windows.net",
+		"wasbs://c@a.blob.core.windows.net/d":  "abfss://c@a.dfs.core.windows.net/d",
+	} {
+		url, err := unityCatalogURL(source)
+		assert.NoError(t, err, source)
+		assert.Equal(t, expected, url, source)
+	}
+	for source, expected := range map[string]string{
+		"adl://a.azuredatalakestore.net/dir": "ADLS Gen1 isn't supported by Unity Catalog: adl://a.azuredatalakestore.net/dir",
+		"wasbs://c@a.example.com":            "invalid Azure Blob Storage source: wasbs://c@a.example.com",
+		"dbfs:/FileStore":                    "invalid mount source: dbfs:/FileStore",
+		"ftp://example.com":                  "ftp:// isn't supported by Unity Catalog: ftp://example.com",
+	} {
+		_, err := unityCatalogURL(source)
+		assert.EqualError(t, err, expected, source)
+	}
+}
+
+var mountMigrationHCL = `
+mount_name = "raw data"
+source = "s3a://bucket/raw"
+catalog_name = "main"
+schema_name = "default"
+volume_name = "raw"
+`
+
+var mountExistsFixture = qa.HTTPFixture{
+	Method:   "GET",
+	Resource: "/api/2.0/dbfs/get-status?path=%2Fmnt%2Fraw+data",
+	Response: files.FileInfo{
+		Path:  "/mnt/raw data",
+		IsDir: true,
+	},
+}
+
+var volumeMissingFixture = qa.HTTPFixture{
+	Method:   "GET",
+	Resource: "/api/2.1/unity-catalog/volumes/main.default.raw?",
+	Status:   404,
+	Response: apierr.NotFound("Volume not found"),
+}
+
+var mountListFixture = qa.HTTPFixture{
+	Method:   "GET",
+	Resource: "/api/2.0/dbfs/list?path=%2Fmnt%2Fraw+data",
+	Response: files.ListStatusResponse{
+		Files: []files.FileInfo{
+			{
+				Path:  "/mnt/raw data/2023",
+				IsDir: true,
+			},
+			{
+				Path:     "/mnt/raw data/_SUCCESS",
+				FileSize: 10,
+			},
+		},
+	},
+}
+
+func TestMountMigrationData(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			mountExistsFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/external-locations",
+				Response: catalog.ListExternalLocationsResponse{
+					ExternalLocations: []catalog.ExternalLocationInfo{
+						{Name: "other", Url: "s3://other-bucket"},
+					},
+				},
+			},
+			volumeMissingFixture,
+		},
+		Resource:    DataSourceMountMigration(),
+		HCL:         mountMigrationHCL + `credential_name = "cred"`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ApplyAndExpectData(t, map[string]any{
+		"url":                        "s3://bucket/raw",
+		"mount_path":                 "/mnt/raw data",
+		"volume_path":                "/Volumes/main/default/raw",
+		"external_location_name":     "mount_raw_data",
+		"existing_external_location": "",
+		"hcl": `resource "databricks_external_location" "raw_data" {
+  name            = "mount_raw_data"
+  url             = "s3://bucket/raw"
+  credential_name = "cred"
+  comment         = "Migrated from dbfs:/mnt/raw data"
+}
+
+resource "databricks_volume" "raw_data" {
+  name             = "raw"
+  catalog_name     = "main"
+  schema_name      = "default"
+  volume_type      = "EXTERNAL"
+  storage_location = databricks_external_location.raw_data.url
+  comment          = "Migrated from dbfs:/mnt/raw data"
+}
+`,
+	})
+}
+
+func TestMountMigrationData_ExistingLocation(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			mountExistsFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/external-locations",
+				Response: catalog.ListExternalLocationsResponse{
+					ExternalLocations: []catalog.ExternalLocationInfo{
+						{Name: "bucket", Url: "s3://bucket/"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/volumes/main.default.raw?",
+				Response: catalog.VolumeInfo{
+					StorageLocation: "s3://bucket/raw/",
+				},
+			},
+			mountListFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/fs/get-status?path=%2FVolumes%2Fmain%2Fdefault%2Fraw%2F2023",
+				Response: files.FileInfo{
+					Path:  "/Volumes/main/default/raw/2023",
+					IsDir: true,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/fs/get-status?path=%2FVolumes%2Fmain%2Fdefault%2Fraw%2F_SUCCESS",
+				Response: files.FileInfo{
+					Path:     "/Volumes/main/default/raw/_SUCCESS",
+					FileSize: 10,
+				},
+			},
+		},
+		Resource:    DataSourceMountMigration(),
+		HCL:         mountMigrationHCL,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ApplyAndExpectData(t, map[string]any{
+		"external_location_name":     "bucket",
+		"existing_external_location": "bucket",
+		"checked_paths":              []any{"2023", "_SUCCESS"},
+		"hcl": `resource "databricks_volume" "raw_data" {
+  name             = "raw"
+  catalog_name     = "main"
+  schema_name      = "default"
+  volume_type      = "EXTERNAL"
+  storage_location = "s3://bucket/raw"
+  comment          = "Migrated from dbfs:/mnt/raw data"
+}
+`,
+	})
+}
+
+func TestMountMigrationData_OverlappingLocation(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			mountExistsFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/external-locations",
+				Response: catalog.ListExternalLocationsResponse{
+					ExternalLocations: []catalog.ExternalLocationInfo{
+						{Name: "nested", Url: "s3://bucket/raw/nested"},
+					},
+				},
+			},
+		},
+		Resource:    DataSourceMountMigration(),
+		HCL:         mountMigrationHCL,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "external location nested (s3://bucket/raw/nested) overlaps with s3://bucket/raw, use a volume in it instead")
+}
+
+func TestMountMigrationData_NoCredential(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			mountExistsFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/external-locations",
+				Response: catalog.ListExternalLocationsResponse{},
+			},
+		},
+		Resource:    DataSourceMountMigration(),
+		HCL:         mountMigrationHCL,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "credential_name is required, because there's no external location for s3://bucket/raw")
+}
+
+func TestMountMigrationData_VolumeMismatch(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			mountExistsFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/external-locations",
+				Response: catalog.ListExternalLocationsResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/volumes/main.default.raw?",
+				Response: catalog.VolumeInfo{
+					StorageLocation: "s3://bucket/other",
+				},
+			},
+		},
+		Resource:    DataSourceMountMigration(),
+		HCL:         mountMigrationHCL + `credential_name = "cred"`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "volume main.default.raw already exists with storage location s3://bucket/other, but the mount points to s3://bucket/raw")
+}
+
+func TestMountMigrationData_NoMount(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/dbfs/get-status?path=%2Fmnt%2Fraw+data",
+				Status:   404,
+				Response: apierr.NotFound("No file or directory exists on path /mnt/raw data."),
+			},
+		},
+		Resource:    DataSourceMountMigration(),
+		HCL:         mountMigrationHCL,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "mount /mnt/raw data doesn't exist")
+}
+
+func TestMountMigrationData_DifferentFiles(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			mountExistsFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/external-locations",
+				Response: catalog.ListExternalLocationsResponse{
+					ExternalLocations: []catalog.ExternalLocationInfo{
+						{Name: "bucket", Url: "s3://bucket"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/volumes/main.default.raw?",
+				Response: catalog.VolumeInfo{
+					StorageLocation: "s3://bucket/raw",
+				},
+			},
+			mountListFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/fs/get-status?path=%2FVolumes%2Fmain%2Fdefault%2Fraw%2F2023",
+				Status:   404,
+				Response: apierr.NotFound("Path not found"),
+			},
+		},
+		Resource:    DataSourceMountMigration(),
+		HCL:         mountMigrationHCL,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "/Volumes/main/default/raw/2023 doesn't exist, but /mnt/raw data/2023 does, "+
+		"so the mount points to other files than s3://bucket/raw")
+}
+
+func TestMountMigrationResourceName(t *testing.T) {
+	for mountName, expected := range map[string]string{
+		"raw data": "raw_data",
+		"2023":     "mount_2023",
+		"-":        "_",
+		"":         "mount_",
+	} {
+		m := mountMigration{MountName: mountName}
+		assert.Equal(t, expected, m.resourceName(), mountName)
+	}
+}
+
+func TestMountMigrationHclEscapesComment(t *testing.T) {
+	m := mountMigration{
+		MountName:                "quoted",
+		VolumeName:               "v",
+		CatalogName:              "c",
+		SchemaName:               "s",
+		URL:                      "s3://bucket",
+		ExistingExternalLocation: "bucket",
+		MountPath:                `/mnt/"quoted"`,
+	}
+	assert.Contains(t, m.hcl(), `comment          = "Migrated from dbfs:/mnt/\"quoted\""`)
+}